@@ -0,0 +1,70 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	patternLineWidth = 1    // Width of the grid pattern lines
+	followGridShade  = 0.15 // How much lighter than the background the follow mode grid is
+)
+
+// backgroundPatterns lists the patterns the background can be drawn with
+var backgroundPatterns = []string{"none", "grid", "checkerboard"}
+
+// validBackgroundPattern rejects a pattern that isn't one of backgroundPatterns
+func validBackgroundPattern(pattern string) error {
+	if !slices.Contains(backgroundPatterns, pattern) {
+		return fmt.Errorf("backgroundPattern %q must be one of %v", pattern, backgroundPatterns)
+	}
+	return nil
+}
+
+// drawBackground fills the screen with the background color and draws the
+// configured pattern over it, fixed to the world at offset x, y from the
+// screen. With the color flash style, both colors fade from the corner flash
+// color after a corner hit, so the flash shows over the whole pattern.
+func (g *Game) drawBackground(screen *ebiten.Image, x, y float64) {
+	background, pattern := g.backgroundColor, g.patternColor
+	name := g.config.BackgroundPattern
+	if name == "none" && g.follow {
+		// Following needs something to show the logo moving against
+		name = "grid"
+		pattern = lerpColor(background, color.RGBA{255, 255, 255, 255}, followGridShade)
+	}
+	if t := g.flashAmount(); t > 0 && g.config.FlashStyle == "color" {
+		background = lerpColor(background, g.cornerFlashColor, t)
+		pattern = lerpColor(pattern, g.cornerFlashColor, t)
+	}
+	screen.Fill(background)
+
+	size := float64(g.config.PatternSize)
+	// The first tile edge on the screen, and which tile it belongs to
+	firstX, firstY := math.Floor(-x/size), math.Floor(-y/size)
+	left, top := firstX*size+x, firstY*size+y
+	switch name {
+	case "grid":
+		for sx := left; sx < g.viewWidth; sx += size {
+			vector.StrokeLine(screen, float32(sx), 0, float32(sx), float32(g.viewHeight), patternLineWidth, pattern, false)
+		}
+		for sy := top; sy < g.viewHeight; sy += size {
+			vector.StrokeLine(screen, 0, float32(sy), float32(g.viewWidth), float32(sy), patternLineWidth, pattern, false)
+		}
+	case "checkerboard":
+		for row := 0; top+float64(row)*size < g.viewHeight; row++ {
+			for col := 0; left+float64(col)*size < g.viewWidth; col++ {
+				if (int(firstX+firstY)+row+col)&1 == 0 {
+					continue
+				}
+				vector.DrawFilledRect(screen, float32(left+float64(col)*size), float32(top+float64(row)*size),
+					float32(size), float32(size), pattern, false)
+			}
+		}
+	}
+}
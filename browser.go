@@ -0,0 +1,7 @@
+package dvdlogo
+
+import "runtime"
+
+// inBrowser is true in the WebAssembly build, which runs in a web page with
+// no file system to save screenshots and recordings to
+const inBrowser = runtime.GOOS == "js"
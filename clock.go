@@ -0,0 +1,60 @@
+package dvdlogo
+
+import (
+	_ "embed"
+	"fmt"
+	"image/color"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	textv2 "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
+)
+
+//go:embed gomonobold.ttf
+var clockFontData []byte // Embedded Go Mono Bold, monospaced so the digits don't jitter
+
+const clockFontSize = 36
+
+// newClockFace parses the clock font from data at clockFontSize, falling back
+// to the built-in bitmap font if it can't be parsed
+func newClockFace(data []byte) textv2.Face {
+	f, err := opentype.Parse(data)
+	if err == nil {
+		var face font.Face
+		face, err = opentype.NewFace(f, &opentype.FaceOptions{
+			Size:    clockFontSize,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err == nil {
+			return textv2.NewGoXFace(face)
+		}
+	}
+	log.Printf("Could not load the clock font: %v, using the built-in font instead", err)
+	return textv2.NewGoXFace(basicfont.Face7x13)
+}
+
+// formatClock formats d as HH:MM:SS
+func formatClock(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// drawClock shows the elapsed time in large digits in the top-right corner
+func (g *Game) drawClock(screen *ebiten.Image) {
+	str := formatClock(g.elapsed())
+	draw := func(offset float64, clr color.Color) {
+		op := &textv2.DrawOptions{}
+		op.PrimaryAlign = textv2.AlignEnd
+		op.GeoM.Translate(g.viewWidth-hudMargin+offset, hudMargin+offset)
+		op.ColorScale.ScaleWithColor(clr)
+		textv2.Draw(screen, str, g.clockFace, op)
+	}
+	// Drop shadow first, like the HUD text
+	draw(2, color.Black)
+	draw(0, color.White)
+}
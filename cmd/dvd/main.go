@@ -0,0 +1,219 @@
+// Command dvd runs the DVD logo bouncer in a window
+package main
+
+import (
+	"flag"
+	"image"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"example.com/dvdlogo"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// isFlagSet reports whether the named flag was given on the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func main() {
+	defaultConfigFile, err := dvdlogo.UserConfigFile("config.json")
+	if err != nil {
+		log.Printf("Could not locate config dir, using the built-in settings: %v", err)
+	}
+
+	defaultSessionFile, _ := dvdlogo.UserConfigFile("session.json") // Same error as above, reported once
+
+	configPath := flag.String("config", defaultConfigFile, "path to a JSON file overriding the built-in settings")
+	sessionPath := flag.String("stats", defaultSessionFile, "path to write the session stats to on quit, empty to disable")
+	logoPath := flag.String("logo", "", "path to a PNG or JPEG image to use instead of the built-in logo, - to read it from stdin")
+	randomColors := flag.Bool("random-colors", false, "pick a random logo color on each bounce instead of cycling in order")
+	rainbow := flag.Bool("rainbow", false, "turn the logo hue smoothly around the color wheel instead of changing colors on bounces")
+	count := flag.Int("count", 1, "number of logos to bounce around")
+	headless := flag.Int("headless", 0, "run this many physics ticks without a window and print the results")
+	background := flag.String("background", "", "background color as #rrggbb, overriding the config file")
+	flashColor := flag.String("flash-color", "", "corner flash color as #rrggbb, overriding the config file")
+	flashStyle := flag.String("flash-style", "", "corner flash style, color or invert, overriding the config file")
+	assist := flag.Bool("assist", false, "gently steer the logo towards corners, corner hits are no longer legit")
+	gravity := flag.Bool("gravity", false, "pull the logo down, losing energy on every floor bounce until it settles")
+	wrap := flag.Bool("wrap", false, "wrap the logo around to the opposite edge instead of bouncing, there are no corner hits")
+	bounceJitter := flag.Float64("bounce-jitter", 0, "move the logo up to this many pixels further off the wall on each bounce, overriding the config file")
+	squash := flag.Float64("squash", 0, "squash the logo by this share, such as 0.3, on every wall bounce and let it spring back, overriding the config file")
+	wobble := flag.Float64("wobble", 0, "turn the logo by a random angle of up to this many degrees on every wall bounce, keeping its speed")
+	startX := flag.Float64("x", 0, "start the first logo at this x position instead of a random one")
+	startY := flag.Float64("y", 0, "start the first logo at this y position instead of a random one")
+	noMouse := flag.Bool("no-mouse", false, "ignore the mouse buttons, so clicks don't pull or push the logo")
+	screensaver := flag.Bool("screensaver", false, "quit on any key press, click or mouse movement, like a real screensaver")
+	boss := flag.Bool("boss", false, "put a big stationary logo in the middle for the others to bounce off")
+	maxVelocity := flag.Float64("max-velocity", 0, "velocity cap for both axes, overriding the config file")
+	maxVelocityX := flag.Float64("max-velocity-x", 0, "horizontal velocity cap, overriding -max-velocity")
+	maxVelocityY := flag.Float64("max-velocity-y", 0, "vertical velocity cap, overriding -max-velocity")
+	noCelebration := flag.Bool("no-corner-celebration", false, "keep the background color on corner hits instead of flashing, like cornerFlashFrames 0")
+	fitLogo := flag.Int("fit-logo", 0, "size the window to the logo's aspect ratio with its longer side this many pixels, capped at 3:1")
+	logoScale := flag.Float64("logo-scale", 0, "logo width as a fraction of the screen width, such as 0.15, overriding logoWidth")
+	duration := flag.Duration("duration", 0, "quit after the session has run this long, such as 30m, not counting pauses (default forever)")
+	target := flag.Int("target", 0, "time trial: stop with the final time once this many corners are hit")
+	tight := flag.Bool("tight", false, "bounce off the walls with the opaque part of the logo, ignoring transparent padding")
+	trace := flag.String("trace", "", "steer the first logo along the letters of this word, sketching it on the screen")
+	paused := flag.Bool("paused", false, "start with the pause menu open")
+	recordPath := flag.String("record", "", "write the keyboard and mouse input of every tick to this replay file")
+	replayPath := flag.String("replay", "", "play back a replay file instead of reading the keyboard and mouse, with its recorded seed")
+	dumpInterval := flag.Int("dump-interval", 0, "print the state as a JSON line to stdout every this many ticks, also in -headless mode")
+	heatmapPath := flag.String("heatmap", "", "write a grayscale PNG of where the logo spent its time to this file on quit")
+	vsync := flag.Bool("vsync", true, "sync drawing to the display refresh rate, false to draw as fast as possible")
+	tps := flag.Int("tps", ebiten.DefaultTPS, "physics ticks per second, the logo covers the same distance per second at any rate")
+	httpAddr := flag.String("http", "", "serve the live state as JSON at /state on this address, such as :8080, not in -headless mode")
+	seed := flag.Int64("seed", 0, "seed for the random start and colors, for reproducible runs (default time based)")
+	flag.Parse()
+
+	// Without a config dir, such as in the browser, there's no file to read
+	cfg := dvdlogo.DefaultConfig()
+	if *configPath != "" {
+		cfg, err = dvdlogo.LoadConfig(*configPath)
+		if err != nil {
+			log.Fatalf("Invalid config: %v", err)
+		}
+	}
+	if *tps <= 0 {
+		log.Fatalf("-tps must be positive, got %d", *tps)
+	}
+	ebiten.SetTPS(*tps)
+	ebiten.SetVsyncEnabled(*vsync)
+
+	if *background != "" {
+		cfg.BackgroundColor = *background
+	}
+	if *flashColor != "" {
+		cfg.CornerFlashColor = *flashColor
+	}
+	if isFlagSet("bounce-jitter") {
+		cfg.BounceJitter = *bounceJitter
+	}
+	if isFlagSet("squash") {
+		cfg.Squash = *squash
+	}
+	if *flashStyle != "" {
+		cfg.FlashStyle = *flashStyle
+	}
+	if *noCelebration {
+		// The hits are still counted and chimed, only the flash is skipped
+		cfg.CornerFlashFrames = 0
+	}
+	if isFlagSet("max-velocity") {
+		// A single cap applies to both axes, replacing any per-axis caps from the config file
+		cfg.LogoMaxVelocity = *maxVelocity
+		cfg.LogoMaxVelocityX, cfg.LogoMaxVelocityY = 0, 0
+	}
+	if isFlagSet("max-velocity-x") {
+		cfg.LogoMaxVelocityX = *maxVelocityX
+	}
+	if isFlagSet("max-velocity-y") {
+		cfg.LogoMaxVelocityY = *maxVelocityY
+	}
+
+	opts := dvdlogo.Options{
+		Config:       cfg,
+		Logo:         dvdlogo.LogoOrFallback(*logoPath),
+		Count:        *count,
+		Seed:         *seed,
+		RandomColors: *randomColors,
+		Rainbow:      *rainbow,
+		Assist:       *assist,
+		Gravity:      *gravity,
+		Wrap:         *wrap,
+		Boss:         *boss,
+		Screensaver:  *screensaver,
+		Paused:       *paused,
+		Tight:        *tight,
+		NoMouse:      *noMouse,
+		Wobble:       *wobble,
+		HeatmapPath:  *heatmapPath,
+		DumpInterval: *dumpInterval,
+		Target:       *target,
+		Duration:     *duration,
+		Trace:        *trace,
+	}
+	if !isFlagSet("seed") {
+		opts.Seed = time.Now().UnixNano()
+	}
+	if *replayPath != "" {
+		replay, err := dvdlogo.LoadReplay(*replayPath)
+		if err != nil {
+			log.Fatalf("Could not load replay: %v", err)
+		}
+		opts.Replay = replay
+		opts.Seed = replay.Seed
+	}
+	if isFlagSet("x") {
+		opts.StartX = startX
+	}
+	if isFlagSet("y") {
+		opts.StartY = startY
+	}
+	if *fitLogo != 0 {
+		if err := opts.FitScreenToLogo(*fitLogo); err != nil {
+			log.Fatalf("Could not fit the window to the logo: %v", err)
+		}
+	}
+	if isFlagSet("logo-scale") {
+		// After -fit-logo, which picks the screen size the fraction applies to
+		if err := opts.ScaleLogoToScreen(*logoScale); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := opts.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	if *headless > 0 {
+		dvdlogo.NewGame(opts).RunHeadless(*headless)
+		return
+	}
+
+	statsPath, err := dvdlogo.UserConfigFile("stats.json")
+	if err != nil {
+		log.Printf("Could not locate config dir, lifetime stats won't be saved: %v", err)
+	}
+	if opts.Replay == nil {
+		// Replayed hits were already counted when they were recorded
+		opts.StatsPath = statsPath
+	}
+	opts.SessionPath = *sessionPath
+	opts.ConfigPath = *configPath
+	opts.Sound = true
+	if *recordPath != "" {
+		f, err := os.Create(*recordPath)
+		if err != nil {
+			log.Fatalf("Could not create replay file: %v", err)
+		}
+		defer f.Close()
+		opts.Record = f
+	}
+	game := dvdlogo.NewGame(opts)
+	if *httpAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/state", game)
+		go func() {
+			// The game keeps running without the server, like without stats
+			log.Printf("State server stopped: %v", http.ListenAndServe(*httpAddr, mux))
+		}()
+	}
+
+	ebiten.SetWindowSize(opts.ScreenWidth, opts.ScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetWindowClosingHandled(true) // Let Update save the stats before the window goes
+	ebiten.SetWindowTitle("DVD Logo Bouncer")
+	ebiten.SetWindowIcon([]image.Image{game.Icon()})
+
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,62 @@
+package dvdlogo
+
+import "math"
+
+// collisionPasses bounds how many times overlaps are resolved per frame, so
+// a pile-up where separating one pair pushes into another settles quickly
+const collisionPasses = 4
+
+// collidePair pushes two overlapping logos apart along the axis with the least
+// penetration and exchanges their velocities along it. Velocities are only
+// exchanged while the logos approach each other, so a pair that is still
+// overlapping but already separating doesn't flip back and jitter.
+func collidePair(a, b *Logo) bool {
+	overlapX := math.Min(a.x+a.width, b.x+b.width) - math.Max(a.x, b.x)
+	overlapY := math.Min(a.y+a.height, b.y+b.height) - math.Max(a.y, b.y)
+	if overlapX <= 0 || overlapY <= 0 {
+		return false
+	}
+
+	if overlapX < overlapY {
+		dir := 1.0 // a is left of b
+		if a.x > b.x {
+			dir = -1
+		}
+		a.x -= dir * overlapX / 2
+		b.x += dir * overlapX / 2
+		if (b.velocityX-a.velocityX)*dir < 0 {
+			a.velocityX, b.velocityX = b.velocityX, a.velocityX
+		}
+	} else {
+		dir := 1.0 // a is above b
+		if a.y > b.y {
+			dir = -1
+		}
+		a.y -= dir * overlapY / 2
+		b.y += dir * overlapY / 2
+		if (b.velocityY-a.velocityY)*dir < 0 {
+			a.velocityY, b.velocityY = b.velocityY, a.velocityY
+		}
+	}
+	return true
+}
+
+// collideLogos resolves overlaps between every pair of logos, keeping them
+// on the screen so a push can never tunnel a logo through a wall
+func (g *Game) collideLogos() {
+	for range collisionPasses {
+		settled := true
+		for i := range g.logos {
+			for j := i + 1; j < len(g.logos); j++ {
+				if collidePair(&g.logos[i], &g.logos[j]) {
+					g.keepOnScreen(&g.logos[i])
+					g.keepOnScreen(&g.logos[j])
+					settled = false
+				}
+			}
+		}
+		if settled {
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
+)
+
+// flashStyles lists how a corner hit can flash: by fading the background
+// from the flash color, or by inverting the colors of the whole frame
+var flashStyles = []string{"color", "invert"}
+
+// validFlashStyle rejects a style that isn't one of flashStyles
+func validFlashStyle(style string) error {
+	if !slices.Contains(flashStyles, style) {
+		return fmt.Errorf("flashStyle %q must be one of %v", style, flashStyles)
+	}
+	return nil
+}
+
+// flashAmount returns how strong the corner flash is this frame, from 1 right
+// after a hit down to 0 once it has faded
+func (g *Game) flashAmount() float64 {
+	if g.cornerFlashFrames == 0 {
+		return 0
+	}
+	return float64(g.cornerFlashFrames) / float64(g.config.CornerFlashFrames)
+}
+
+// drawComposited draws the scene with drawScene, moved by the shake offset
+// and with its colors inverted by an invert style flash. Either goes through
+// an offscreen image, so everything on it moves and flashes together. A
+// moved scene leaves a gap at the edges, where the background shows.
+func (g *Game) drawComposited(screen *ebiten.Image, drawScene func(*ebiten.Image)) {
+	dx, dy := g.shakeOffset()
+	invert := 0.0
+	if g.config.FlashStyle == "invert" {
+		invert = g.flashAmount()
+	}
+	if dx == 0 && dy == 0 && invert == 0 {
+		drawScene(screen)
+		return
+	}
+
+	size := screen.Bounds().Size()
+	if g.scene == nil || g.scene.Bounds().Size() != size {
+		if g.scene != nil {
+			g.scene.Deallocate()
+		}
+		g.scene = ebiten.NewImage(size.X, size.Y)
+	}
+	g.scene.Clear()
+	drawScene(g.scene)
+
+	screen.Fill(g.backgroundColor)
+	// Blend each channel from c to 1-c, fully inverted right after the hit
+	var cm colorm.ColorM
+	cm.Scale(1-2*invert, 1-2*invert, 1-2*invert, 1)
+	cm.Translate(invert, invert, invert, 0)
+	op := &colorm.DrawImageOptions{}
+	op.GeoM.Translate(dx, dy)
+	colorm.DrawImage(screen, g.scene, cm, op)
+}
@@ -0,0 +1,330 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"io/fs"
+	"log"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultBackgroundColor  = "#0000ff" // Blue
+	defaultCornerFlashColor = "#00ff00" // Green
+	defaultPatternColor     = "#0000c0" // Darker blue
+)
+
+// Config holds the tunables read from config.json
+type Config struct {
+	ScreenWidth       int     `json:"screenWidth"`
+	ScreenHeight      int     `json:"screenHeight"`
+	MarginTop         int     `json:"marginTop"` // Pixels between a screen edge and the wall the logos bounce off
+	MarginBottom      int     `json:"marginBottom"`
+	MarginLeft        int     `json:"marginLeft"`
+	MarginRight       int     `json:"marginRight"`
+	LogoWidth         float64 `json:"logoWidth"`
+	LogoStartVelocity float64 `json:"logoStartVelocity"`
+	LogoMaxVelocity   float64 `json:"logoMaxVelocity"`
+	LogoMaxVelocityX  float64 `json:"logoMaxVelocityX"` // Per-axis caps, 0 uses logoMaxVelocity
+	LogoMaxVelocityY  float64 `json:"logoMaxVelocityY"`
+	LogoFrames        int     `json:"logoFrames"`     // Frames in a sprite sheet logo, 1 for a still logo
+	LogoFrameWidth    int     `json:"logoFrameWidth"` // Frame size in the sheet, 0 for the whole sheet
+	LogoFrameHeight   int     `json:"logoFrameHeight"`
+	LogoFrameRate     float64 `json:"logoFrameRate"` // Animation frames per second
+	CornerTolerance   float64 `json:"cornerTolerance"`
+	BounceJitter      float64 `json:"bounceJitter"`     // Random extra distance off the wall after a bounce, in pixels, 0 reflects exactly
+	PerfectTolerance  float64 `json:"perfectTolerance"` // Corner misses up to this many pixels count as perfect
+	CornerFlashFrames int     `json:"cornerFlashFrames"`
+	BackgroundColor   string  `json:"backgroundColor"`
+	CornerFlashColor  string  `json:"cornerFlashColor"`
+	FlashStyle        string  `json:"flashStyle"`        // One of flashStyles
+	BackgroundPattern string  `json:"backgroundPattern"` // One of backgroundPatterns
+	PatternColor      string  `json:"patternColor"`      // Second background color, of the grid lines or every other square
+	PatternSize       int     `json:"patternSize"`       // Pixels between grid lines, or per checkerboard square
+	Volume            float64 `json:"volume"`
+	RotationSpeed     float64 `json:"rotationSpeed"`  // Radians per second, 0 disables spinning
+	RainbowSpeed      float64 `json:"rainbowSpeed"`   // Degrees per second the hue turns in rainbow mode
+	Gravity           float64 `json:"gravity"`        // Downward velocity added per tick in gravity mode
+	Restitution       float64 `json:"restitution"`    // Share of the velocity kept on a floor bounce
+	CornerSpeedUp     float64 `json:"cornerSpeedUp"`  // Velocity multiplier on every corner hit, 1 disables
+	CornerSpeedCap    float64 `json:"cornerSpeedCap"` // Highest velocity the corner speed-up reaches
+	WellStrength      float64 `json:"wellStrength"`   // Pull of the mouse gravity well per tick, divided by the distance to the cursor
+	StreakSpeed       float64 `json:"streakSpeed"`    // Afterimages only show above this speed, 0 always shows them
+	StreakLength      int     `json:"streakLength"`   // Number of afterimages, 0 disables them
+	MinimapWidth      int     `json:"minimapWidth"`   // Width of the minimap shown with N
+	MinimapCorner     string  `json:"minimapCorner"`  // One of minimapCorners
+	LogoFilter        string  `json:"logoFilter"`     // One of logoFilters
+	FocusLoss         string  `json:"focusLoss"`      // One of focusLosses, what to do while the window is in the background
+	HeatmapColumns    int     `json:"heatmapColumns"` // Grid size of the -heatmap PNG
+	HeatmapRows       int     `json:"heatmapRows"`
+	ComboWindow       float64 `json:"comboWindow"`    // Seconds between corner hits that keep a combo going, 0 disables combos
+	ShakeFrames       int     `json:"shakeFrames"`    // Ticks the screen shakes after a corner hit, 0 disables the shake
+	ShakeMagnitude    float64 `json:"shakeMagnitude"` // Largest shake offset in pixels, right after the hit
+	Squash            float64 `json:"squash"`         // Share the logo squashes by on a wall bounce, 0 disables it
+	Muted             bool    `json:"muted"`
+	MilestoneInterval float64 `json:"milestoneInterval"` // Seconds of session time between milestones, 0 disables them
+	MilestoneSound    bool    `json:"milestoneSound"`    // Chime on every milestone, on top of the banner
+
+	Keys map[string]string `json:"keys"` // Action to key name, see defaultKeyBindings
+}
+
+// DefaultConfig returns the built-in tunables, used for anything the config file leaves out
+func DefaultConfig() Config {
+	return Config{
+		ScreenWidth:       screenWidth,
+		ScreenHeight:      screenHeight,
+		LogoWidth:         logoWidth,
+		LogoStartVelocity: logoStartVelocity,
+		LogoMaxVelocity:   logoMaxVelocity,
+		CornerTolerance:   cornerTolerance,
+		PerfectTolerance:  perfectTolerance,
+		LogoFrames:        1,
+		LogoFrameRate:     10,
+		CornerFlashFrames: 30,
+		BackgroundColor:   defaultBackgroundColor,
+		CornerFlashColor:  defaultCornerFlashColor,
+		FlashStyle:        "color",
+		BackgroundPattern: "none",
+		PatternColor:      defaultPatternColor,
+		PatternSize:       64,
+		Volume:            1,
+		RotationSpeed:     0.5,
+		RainbowSpeed:      60,
+		Gravity:           0.15,
+		Restitution:       0.8,
+		CornerSpeedUp:     1.05,
+		CornerSpeedCap:    6,
+		WellStrength:      wellStrength,
+		StreakSpeed:       3.5,
+		StreakLength:      8,
+		MinimapWidth:      160,
+		MinimapCorner:     "bottom-right",
+		LogoFilter:        "linear",
+		FocusLoss:         "run",
+		HeatmapColumns:    80,
+		HeatmapRows:       60,
+		ComboWindow:       30,
+		ShakeFrames:       20,
+		ShakeMagnitude:    8,
+		MilestoneInterval: 300,
+		MilestoneSound:    true,
+		Keys:              maps.Clone(defaultKeyBindings),
+	}
+}
+
+// UserConfigFile returns the location of the named file inside the user config dir
+func UserConfigFile(name string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "dvdlogo", name), nil
+}
+
+// LoadConfig reads the config file at path on top of the defaults. A missing
+// file isn't an error, the defaults are used as they are.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s is not valid JSON: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// validate rejects values the game can't run with
+func (c Config) validate() error {
+	if c.ScreenWidth <= 0 || c.ScreenHeight <= 0 {
+		return fmt.Errorf("screen size %dx%d must be positive", c.ScreenWidth, c.ScreenHeight)
+	}
+	if c.MarginTop < 0 || c.MarginBottom < 0 || c.MarginLeft < 0 || c.MarginRight < 0 {
+		return fmt.Errorf("margins %d, %d, %d and %d must not be negative", c.MarginTop, c.MarginBottom, c.MarginLeft, c.MarginRight)
+	}
+	innerWidth, _ := c.innerSize(c.ScreenWidth, c.ScreenHeight)
+	if c.LogoWidth <= 0 || c.LogoWidth >= float64(innerWidth) {
+		return fmt.Errorf("logoWidth %v must be positive and narrower than the screen width %d inside the margins", c.LogoWidth, innerWidth)
+	}
+	if c.LogoMaxVelocity < logoMinVelocity || c.LogoMaxVelocity > logoVelocityCeiling {
+		return fmt.Errorf("logoMaxVelocity %v must be between %v and %v", c.LogoMaxVelocity, logoMinVelocity, logoVelocityCeiling)
+	}
+	if c.LogoMaxVelocityX != 0 && (c.LogoMaxVelocityX < logoMinVelocity || c.LogoMaxVelocityX > logoVelocityCeiling) {
+		return fmt.Errorf("logoMaxVelocityX %v must be 0 or between %v and %v", c.LogoMaxVelocityX, logoMinVelocity, logoVelocityCeiling)
+	}
+	if c.LogoMaxVelocityY != 0 && (c.LogoMaxVelocityY < logoMinVelocity || c.LogoMaxVelocityY > logoVelocityCeiling) {
+		return fmt.Errorf("logoMaxVelocityY %v must be 0 or between %v and %v", c.LogoMaxVelocityY, logoMinVelocity, logoVelocityCeiling)
+	}
+	maxX, maxY := c.maxVelocities()
+	if c.LogoStartVelocity <= 0 || c.LogoStartVelocity > math.Min(maxX, maxY) {
+		return fmt.Errorf("logoStartVelocity %v must be positive and at most the max velocity %v", c.LogoStartVelocity, math.Min(maxX, maxY))
+	}
+	if c.LogoFrames < 1 {
+		return fmt.Errorf("logoFrames %d must be at least 1", c.LogoFrames)
+	}
+	if c.LogoFrameWidth < 0 || c.LogoFrameHeight < 0 {
+		return fmt.Errorf("logo frame size %dx%d must not be negative", c.LogoFrameWidth, c.LogoFrameHeight)
+	}
+	if c.LogoFrameRate <= 0 {
+		return fmt.Errorf("logoFrameRate %v must be positive", c.LogoFrameRate)
+	}
+	if c.CornerTolerance < 0 {
+		return fmt.Errorf("cornerTolerance %v must not be negative", c.CornerTolerance)
+	}
+	if c.BounceJitter < 0 {
+		return fmt.Errorf("bounceJitter %v must not be negative", c.BounceJitter)
+	}
+	if c.PerfectTolerance < 0 {
+		return fmt.Errorf("perfectTolerance %v must not be negative", c.PerfectTolerance)
+	}
+	if c.CornerFlashFrames < 0 {
+		return fmt.Errorf("cornerFlashFrames %v must not be negative", c.CornerFlashFrames)
+	}
+	if c.ShakeFrames < 0 || c.ShakeMagnitude < 0 {
+		return fmt.Errorf("shakeFrames %v and shakeMagnitude %v must not be negative", c.ShakeFrames, c.ShakeMagnitude)
+	}
+	if c.Squash < 0 || c.Squash >= 1 {
+		return fmt.Errorf("squash %v must be at least 0 and below 1", c.Squash)
+	}
+	if c.RainbowSpeed < 0 {
+		return fmt.Errorf("rainbowSpeed %v must not be negative", c.RainbowSpeed)
+	}
+	if c.Gravity < 0 {
+		return fmt.Errorf("gravity %v must not be negative", c.Gravity)
+	}
+	if c.Restitution < 0 || c.Restitution > 1 {
+		return fmt.Errorf("restitution %v must be between 0 and 1", c.Restitution)
+	}
+	if c.CornerSpeedUp < 1 || c.CornerSpeedUp > 2 {
+		return fmt.Errorf("cornerSpeedUp %v must be between 1 and 2", c.CornerSpeedUp)
+	}
+	if c.CornerSpeedCap < math.Max(maxX, maxY) || c.CornerSpeedCap > logoVelocityCeiling {
+		return fmt.Errorf("cornerSpeedCap %v must be between the max velocity %v and %v", c.CornerSpeedCap, math.Max(maxX, maxY), logoVelocityCeiling)
+	}
+	if c.WellStrength < 0 {
+		return fmt.Errorf("wellStrength %v must not be negative", c.WellStrength)
+	}
+	if c.StreakSpeed < 0 {
+		return fmt.Errorf("streakSpeed %v must not be negative", c.StreakSpeed)
+	}
+	if c.StreakLength < 0 || c.StreakLength > trailLength {
+		return fmt.Errorf("streakLength %d must be between 0 and %d", c.StreakLength, trailLength)
+	}
+	if c.MinimapWidth <= 0 || c.MinimapWidth > c.ScreenWidth {
+		return fmt.Errorf("minimapWidth %d must be positive and at most the screen width %d", c.MinimapWidth, c.ScreenWidth)
+	}
+	if err := validMinimapCorner(c.MinimapCorner); err != nil {
+		return err
+	}
+	if err := validFlashStyle(c.FlashStyle); err != nil {
+		return err
+	}
+	if err := validBackgroundPattern(c.BackgroundPattern); err != nil {
+		return err
+	}
+	if c.PatternSize <= 0 {
+		return fmt.Errorf("patternSize %d must be positive", c.PatternSize)
+	}
+	if err := validLogoFilter(c.LogoFilter); err != nil {
+		return err
+	}
+	if err := validFocusLoss(c.FocusLoss); err != nil {
+		return err
+	}
+	if c.HeatmapColumns <= 0 || c.HeatmapRows <= 0 {
+		return fmt.Errorf("heatmap grid %dx%d must be positive", c.HeatmapColumns, c.HeatmapRows)
+	}
+	if c.ComboWindow < 0 {
+		return fmt.Errorf("comboWindow %v must not be negative", c.ComboWindow)
+	}
+	if c.MilestoneInterval < 0 {
+		return fmt.Errorf("milestoneInterval %v must not be negative", c.MilestoneInterval)
+	}
+	if c.Volume < 0 || c.Volume > 1 {
+		return fmt.Errorf("volume %v must be between 0 and 1", c.Volume)
+	}
+	return nil
+}
+
+// maxVelocities returns the velocity cap of each axis, using logoMaxVelocity
+// for an axis that doesn't have its own
+func (c Config) maxVelocities() (x, y float64) {
+	x, y = c.LogoMaxVelocityX, c.LogoMaxVelocityY
+	if x == 0 {
+		x = c.LogoMaxVelocity
+	}
+	if y == 0 {
+		y = c.LogoMaxVelocity
+	}
+	return x, y
+}
+
+// parseHexColor parses a #rrggbb or #rgb color
+func parseHexColor(s string) (color.RGBA, error) {
+	hex, ok := strings.CutPrefix(s, "#")
+	if ok && len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if !ok || len(hex) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("%q is not a #rrggbb or #rgb color", s)
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 255}, nil
+}
+
+// resolveColor parses the hex color set for name, falling back to the
+// default with a warning when it's malformed
+func resolveColor(name, hex, fallback string) color.RGBA {
+	c, err := parseHexColor(hex)
+	if err != nil {
+		log.Printf("Invalid %s: %v, using %s instead", name, err, fallback)
+		c, _ = parseHexColor(fallback)
+	}
+	return c
+}
+
+// updateConfig sets the given settings in the config file at path, creating
+// it and its directory if needed. The rest of the file is kept as it was,
+// though in sorted order, so settings it leaves out still follow the defaults.
+func updateConfig(path string, changes map[string]any) error {
+	settings := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("%s is not valid JSON: %w", path, err)
+		}
+	}
+	if settings == nil {
+		// The file held null
+		settings = map[string]json.RawMessage{}
+	}
+	for key, value := range changes {
+		if settings[key], err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if data, err = json.MarshalIndent(settings, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
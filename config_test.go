@@ -0,0 +1,25 @@
+package dvdlogo
+
+import "testing"
+
+func TestMaxVelocities(t *testing.T) {
+	tests := []struct {
+		name         string
+		all, x, y    float64
+		wantX, wantY float64
+	}{
+		{"single cap", 10, 0, 0, 10, 10},
+		{"own x cap", 10, 14, 0, 14, 10},
+		{"own y cap", 10, 0, 6, 10, 6},
+		{"own caps", 10, 14, 6, 14, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.LogoMaxVelocity, cfg.LogoMaxVelocityX, cfg.LogoMaxVelocityY = tt.all, tt.x, tt.y
+			if x, y := cfg.maxVelocities(); x != tt.wantX || y != tt.wantY {
+				t.Errorf("maxVelocities() = %v, %v, want %v, %v", x, y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
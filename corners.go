@@ -0,0 +1,48 @@
+package dvdlogo
+
+import "fmt"
+
+// Corners of the screen, indexing Game.cornerHits
+const (
+	cornerTopLeft = iota
+	cornerTopRight
+	cornerBottomLeft
+	cornerBottomRight
+)
+
+// cornerLabels are the short names of the corners in the HUD
+var cornerLabels = [4]string{"TL", "TR", "BL", "BR"}
+
+// corner returns which corner of a width x height screen the logo is in,
+// judged by the half of the screen its center is in along each axis
+func (l *Logo) corner(width, height float64) int {
+	c := cornerTopLeft
+	if l.x+l.width/2 >= width/2 {
+		c++
+	}
+	if l.y+l.height/2 >= height/2 {
+		c += cornerBottomLeft
+	}
+	return c
+}
+
+// totalCornerHits returns the corner hits of the session across all corners
+func (g *Game) totalCornerHits() int {
+	total := 0
+	for _, hits := range g.cornerHits {
+		total += hits
+	}
+	return total
+}
+
+// formatCornerHits lists the hits of every corner, like "TL 1  TR 0  BL 2  BR 0"
+func (g *Game) formatCornerHits() string {
+	s := ""
+	for i, hits := range g.cornerHits {
+		if i > 0 {
+			s += "  "
+		}
+		s += fmt.Sprintf("%s %d", cornerLabels[i], hits)
+	}
+	return s
+}
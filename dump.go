@@ -0,0 +1,70 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+)
+
+// LogoSnapshot is the position and velocity of one logo, in pixels and
+// pixels per tick at 60 ticks per second
+type LogoSnapshot struct {
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	VelocityX float64 `json:"velocityX"`
+	VelocityY float64 `json:"velocityY"`
+}
+
+// StateSnapshot is a copy of the state of a game at the end of a tick. It's
+// also the JSON printed by -dump-interval and served by ServeHTTP.
+type StateSnapshot struct {
+	Tick           int            `json:"tick"`
+	CornerHits     int            `json:"cornerHits"`
+	Corners        [4]int         `json:"corners"` // Top-left, top-right, bottom-left and bottom-right
+	PerfectCorners int            `json:"perfectCorners"`
+	ElapsedSeconds float64        `json:"elapsedSeconds"`
+	Logos          []LogoSnapshot `json:"logos"`
+}
+
+// Snapshot returns a copy of the state at the end of the last tick. Unlike
+// the other methods it may be called from any goroutine while the game runs.
+// It never sees a tick halfway, and the game never waits for it.
+func (g *Game) Snapshot() StateSnapshot {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.published
+}
+
+// publish stores the state for Snapshot. Every snapshot gets its own Logos,
+// which is never changed once published, so callers may keep it.
+func (g *Game) publish() {
+	s := g.state()
+	g.mu.Lock()
+	g.published = s
+	g.mu.Unlock()
+}
+
+// state builds a snapshot of the state now, for the game goroutine
+func (g *Game) state() StateSnapshot {
+	s := StateSnapshot{
+		Tick:           g.ticks,
+		CornerHits:     g.totalCornerHits(),
+		Corners:        g.cornerHits,
+		PerfectCorners: g.perfectHits,
+		ElapsedSeconds: g.elapsed().Seconds(),
+		Logos:          make([]LogoSnapshot, len(g.logos)),
+	}
+	for i, l := range g.logos {
+		s.Logos[i] = LogoSnapshot{X: l.x, Y: l.y, VelocityX: l.velocityX, VelocityY: l.velocityY}
+	}
+	return s
+}
+
+// dumpState prints the state as a single JSON line to stdout, for piping
+// into other tools
+func (g *Game) dumpState() {
+	// Stdout isn't buffered, so every line is out as soon as it's encoded
+	if err := json.NewEncoder(os.Stdout).Encode(g.state()); err != nil {
+		log.Printf("Could not dump state: %v", err)
+	}
+}
@@ -1,227 +1,1256 @@
-package main
+// Package dvdlogo bounces a DVD logo around the screen, to be run on its own
+// with cmd/dvd or embedded into another Ebiten game with NewGame.
+package dvdlogo
 
 import (
-	"bytes"
 	_ "embed"
 	"fmt"
+	"image"
 	"image/color"
 	"log"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/audio"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
-	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font/basicfont"
+	textv2 "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 //go:embed dvd-logo.png
 var logoImageData []byte // Embedded the logo image
 
 const (
-	screenWidth       = 800
-	screenHeight      = 600
-	logoWidth         = 120
-	logoStartVelocity = 2
-	logoMaxVelocity   = 3
-	cornerTolerance   = 5
-	nudgeAmount       = 0.5
+	screenWidth         = 800
+	screenHeight        = 600
+	logoWidth           = 120
+	logoStartVelocity   = 2
+	logoMaxVelocity     = 3
+	logoMinVelocity     = 0.5
+	speedUpFactor       = 1.1
+	slowDownFactor      = 0.9
+	logoVelocityCeiling = 12
+	cornerTolerance     = 5
+	perfectTolerance    = 1
+	wellStrength        = 20                     // Default pull of the mouse gravity well per tick, divided by the distance
+	wellMinDistance     = 20                     // Closer than this the pull stops growing, so it can't blow up on top of the cursor
+	keyNudgeAmount      = 0.05                   // Velocity added per tick while an arrow key is held
+	recordBannerFrames  = 120                    // How long the NEW RECORD banner stays up
+	titleInterval       = 200 * time.Millisecond // Least time between window title updates
+	hiddenTitle         = "Untitled"             // Window title while the boss key hides the game
+	slowMotionFactor    = 0.25
+	assistMaxTurn       = 0.0005 // Radians per tick the assist mode may turn a logo
+	maxWobble           = 45     // Degrees the -wobble turn must stay below, beyond that bounces stop looking like bounces
+	maxFitAspect        = 3      // Widest or tallest screen -fit-logo sizes the window to
+	restVelocity        = 0.5    // Slower than this on the floor counts as resting in gravity mode
+	floorFriction       = 0.98   // Share of the velocity kept per tick while sliding on the floor
+	squashTicks         = 12     // Ticks a logo takes to spring back from a wall bounce
+	referenceTPS        = 60     // Velocities are in pixels per tick at this tick rate
 )
 
+// screenPresets are the screen sizes Z cycles through, before going back to
+// following the window size
+var screenPresets = []image.Point{
+	{640, 480},
+	{800, 600},
+	{1024, 768},
+	{1280, 720},
+	{1920, 1080},
+}
+
+// logoPalette holds the tints every logo cycles through on its own wall bounces
+var logoPalette = []color.RGBA{
+	{255, 255, 255, 255}, // White
+	{255, 0, 0, 255},     // Red
+	{255, 165, 0, 255},   // Orange
+	{255, 255, 0, 255},   // Yellow
+	{0, 255, 0, 255},     // Green
+	{0, 255, 255, 255},   // Cyan
+	{255, 0, 255, 255},   // Magenta
+}
+
+// Game is an ebiten.Game bouncing the logos, created with NewGame. Like any
+// ebiten.Game, its methods must all be called from the goroutine running the
+// game, apart from Snapshot and ServeHTTP, which other goroutines may call to
+// read the state while it runs.
 type Game struct {
-	logoX      float64
-	logoY      float64
-	velocityX  float64
-	velocityY  float64
-	cornerHits int
-	startTime  time.Time
-	logoImage  *ebiten.Image
-	logoHeight float64
-	hitCorner  bool
+	config       Config
+	configPath   string // Where config changes are saved, empty to disable
+	logos        []Logo
+	maxVelocityX float64 // Adjusted with page up/down
+	maxVelocityY float64
+	cornerHits   [4]int // Per corner, indexed by cornerTopLeft and the others
+	perfectHits  int    // Corner hits within the perfect corner tolerance of an exact corner
+	startTime    time.Time
+	pausedAt     time.Time     // When the current pause started
+	pausedTotal  time.Duration // Time spent in earlier pauses
+	lastStep     time.Time     // When the physics last advanced, for interpolating in Draw
+	ticks        int           // Physics ticks this session
+	target       int           // Corner hits that end a time trial, 0 for no time trial
+	combo        int           // Corner hits in a row, each within the combo window of the last
+	lastCornerAt time.Duration // Elapsed time of the last corner hit
+	finished     bool          // The time trial target was reached, the physics stop
+	finishTime   time.Duration // Time it took to reach the target
+	dumpInterval int           // Ticks between state dumps to stdout, 0 to only dump on key
+	duration     time.Duration // Session time after which the game quits, 0 to run forever
+
+	lifetimeHits int
+	bestSession  int    // Most corner hits in a single session, including this one
+	statsPath    string // Where lifetime stats are saved, empty to disable
+
+	newRecord   bool // This session beat the best from earlier sessions
+	recordShown int  // Frames left to show the NEW RECORD banner
+
+	milestones     int // Milestone intervals the session time has passed
+	milestoneShown int // Frames left to show the milestone banner
+
+	wallBounces      int
+	distanceTraveled float64 // Pixels covered by all logos this session
+	maxSpeedSeen     float64
+	sessionPath      string // Where session stats are written on quit, empty to disable
+	sessionFlushed   bool
+	heatmap          *heatmap // Time spent over each part of the screen, nil unless saved on quit
+
+	backgroundColor  color.RGBA
+	cornerFlashColor color.RGBA
+	patternColor     color.RGBA
+
+	logoChoices       []logoChoice    // Logos cycled through with L
+	logoIndex         int             // Current one of logoChoices
+	logoFrames        []*ebiten.Image // Sprite sheet frames, a single one for a still logo
+	icon              image.Image     // First frame of the logo source, for the window icon
+	logoWidth         float64         // Size of the hitbox of a logo on screen
+	logoHeight        float64
+	logoScale         float64         // Screen pixels per logo image pixel
+	logoFilter        ebiten.Filter   // How the logo is sampled when scaled
+	hitbox            image.Rectangle // Part of a frame that collides, the opaque pixels with -tight
+	tight             bool            // Cut the hitbox of every logo picked with L down to its opaque pixels
+	hitCorner         bool
+	cornerFlashFrames int     // Frames left of the fading corner flash
+	shakeFrames       int     // Ticks left of the corner hit screen shake
+	width             float64 // Live width of the area the logos bounce in, the screen less the margins
+	height            float64 // Live height of the same area
+	viewWidth         float64 // Live logical screen width, tracked in Layout
+	viewHeight        float64 // Live logical screen height, tracked in Layout
+	screenPreset      int     // Size picked with Z, 0 follows the window, otherwise screenPresets[screenPreset-1]
+
+	rng          *rand.Rand
+	startX       *float64 // Start position of the first logo from -x/-y, nil to randomize
+	startY       *float64
+	randomColors bool
+	autoColor    bool    // Change the logo color on bounces, off to keep a color picked with [ and ]
+	rainbow      bool    // Shift the hue of the logos smoothly instead of changing colors on bounces
+	hue          float64 // Degrees around the color wheel of the rainbow mode
+	assist       bool    // Steer the logos towards corners, so the hits don't count as legit
+	gravity      bool    // Pull the logos down and lose energy on floor bounces
+	wrap         bool    // Leave the screen at one edge and come back at the opposite one
+	noMouse      bool    // Ignore the mouse buttons, so only the keys move the logos
+	wobble       float64 // Largest random turn in radians on a wall bounce, 0 for pure reflection
+
+	rotation      float64 // Current logo angle in radians
+	rotationSpeed float64 // Radians per second, flips sign on corner hits
+
+	slowMotion bool
+	showDebug  bool
+	showHUD    bool
+	showClock  bool
+	showMap    bool
+	follow     bool // Keep the first logo centered and move the world around it, toggled with V
+	clockFace  textv2.Face
+	screenshot bool       // Set by F12, taken at the end of the next Draw
+	recording  *recording // Non-nil while G is recording a GIF
 	paused     bool
+	hidden     bool // The boss key blanked the screen, toggled with '`'
 	terminated bool
-	keyState   map[ebiten.Key]bool
+
+	inSettings      bool                  // The settings menu shows instead of the pause menu
+	stepRequested   bool                  // Advance a single tick while paused, set with '.'
+	hidePaused      bool                  // Whether the game was paused before it was hidden
+	focusPaused     bool                  // Paused without the menu while the window is in the background
+	menuIndex       int                   // Highlighted row of the settings menu
+	settingsChanged bool                  // Settings to save to the config file on continue
+	streakLength    int                   // Afterimages to bring back when trails are turned on again
+	input           input                 // Live, replayed or recorded keyboard and mouse
+	keys            map[string]ebiten.Key // Remappable action keys, see defaultKeyBindings
+	keyState        map[ebiten.Key]bool
+
+	audioContext *audio.Context
+	cornerPlayer *audio.Player
+	bouncePlayer *audio.Player
+	chimePlayer  *audio.Player // Milestone chime
+	volume       float64
+	muted        bool
+	bounceMuted  bool // Silences only the wall bounce tick
+
+	title          string    // Last window title set
+	titleUpdatedAt time.Time // When the title was last checked for changes
+	titleHits      int       // Corner hits in the last title
+
+	bursts   []burst
+	obstacle *rect // The boss logo from -boss, nil without one
+
+	screensaver                bool       // Quit on the first user activity
+	screensaverTicks           int        // Ticks of the startup grace period so far
+	startCursorX, startCursorY int        // Cursor position once the grace period ended
+	screensaverHeld            inputFrame // Keys and buttons held on the last tick, which only count once pressed again
+
+	sketch bool          // Draw a permanent line along the logo paths
+	canvas *ebiten.Image // Holds the sketched lines, created on first use
+	scene  *ebiten.Image // The scene is drawn here to be shaken or inverted
+	world  *ebiten.Image // The world is drawn here to be moved while following a logo
+	trace  *tracer       // Steers the first logo along the -trace word, nil without one
+
+	// The lock only guards published, the copy of the state Snapshot returns.
+	// The game goroutine owns the rest and never waits on Snapshot callers.
+	mu        sync.RWMutex
+	published StateSnapshot
 }
 
+// Update handles input and advances the physics by one tick, then publishes
+// the state for Snapshot
 func (g *Game) Update() error {
+	err := g.update()
+	g.publish()
+	if err == ebiten.Termination {
+		g.quit()
+	}
+	return err
+}
+
+// update is Update up to publishing the state
+func (g *Game) update() error {
+	// A -duration session ends once it has run that long, not counting pauses
+	if g.duration > 0 && g.elapsed() >= g.duration {
+		log.Printf("Session time of %s is up with %d corner hits", g.duration, g.totalCornerHits())
+		return ebiten.Termination
+	}
+
+	// Move on to this tick's input, of which a replay may have run out
+	if !g.input.next() {
+		log.Printf("Replay finished with %d corner hits", g.totalCornerHits())
+		return ebiten.Termination
+	}
+	// Closing the window saves the same as quitting with the quit key, when
+	// the closing is handled with ebiten.SetWindowClosingHandled
+	if g.input.isWindowBeingClosed() {
+		return ebiten.Termination
+	}
+	// Like a real screensaver, any activity ends it
+	if g.screensaver && g.userActive() {
+		return ebiten.Termination
+	}
+	g.resize(g.screenSize(int(g.viewWidth), int(g.viewHeight)))
+
+	// Check for the hide key, '`' by default, to blank the screen. No other key
+	// works while hidden, so showing the game again finds it as it was left.
+	if g.keyJustPressed(g.keys[actionHide]) {
+		g.setHidden(!g.hidden)
+	}
+	if g.hidden {
+		return nil
+	}
+	g.followFocus()
+
 	// Handle key press events
 	g.handleKeyPresses()
+	g.applyVolume()
 
-	if g.paused {
+	if g.paused || g.finished {
+		// Drop the trails so they don't freeze as a smear behind the menu
+		for i := range g.logos {
+			g.logos[i].trail.clear()
+		}
 		if g.terminated {
 			return ebiten.Termination
 		}
+		if g.stepRequested && !g.finished {
+			// Move the timer on by the tick too, so the step takes as long as a running one
+			g.stepRequested = false
+			g.pausedTotal -= time.Duration(g.timeStep() * float64(time.Second))
+			g.tick()
+		}
 		return nil
 	}
 
-	g.logoX += g.velocityX
-	g.logoY += g.velocityY
-	g.hitCorner = false
+	g.tick()
+	g.checkMilestone()
+
+	// Adjust velocity based on mouse and arrow key input. Both add up into a
+	// single nudge, so the velocity is only changed and clamped once per tick.
+	// The left button attracts the logos to the cursor, the right one repels them.
+	keyX, keyY := g.arrowKeyNudge()
+	strength := 0.0
+	if !g.noMouse {
+		if g.input.isMouseButtonPressed(ebiten.MouseButtonLeft) {
+			strength += g.config.WellStrength
+		}
+		if g.input.isMouseButtonPressed(ebiten.MouseButtonRight) {
+			strength -= g.config.WellStrength
+		}
+	}
+	if strength != 0 || keyX != 0 || keyY != 0 {
+		// The cursor is on the screen, the logos are in the moved world
+		x, y := g.input.cursorPosition()
+		camX, camY := g.cameraOffset()
+		cursorX, cursorY := float64(x)+camX, float64(y)+camY
+		dt := g.timeStep()
+		for i := range g.logos {
+			l := &g.logos[i]
+			ax, ay := keyX, keyY
+			if strength != 0 {
+				mouseX, mouseY := l.gravityWell(cursorX, cursorY, strength)
+				ax += mouseX
+				ay += mouseY
+			}
+			l.accelerate(ax, ay, g.maxVelocityX, g.maxVelocityY, dt)
+		}
+	}
+
+	return nil
+}
 
-	// Check for collision with window borders
-	if g.logoX < 0 {
-		g.logoX = 0
-		g.velocityX = -g.velocityX
+// step advances the physics by one tick. It reads no input, so it can also
+// be driven without a window.
+func (g *Game) step() {
+	dt := g.timeStep()
+	g.ticks++
+	wasHitCorner := g.hitCorner
+	g.hitCorner = false
+	if g.cornerFlashFrames > 0 {
+		g.cornerFlashFrames--
 	}
-	if g.logoX+logoWidth > screenWidth {
-		g.logoX = screenWidth - logoWidth
-		g.velocityX = -g.velocityX
+	if g.shakeFrames > 0 {
+		g.shakeFrames--
 	}
-	if g.logoY < 0 {
-		g.logoY = 0
-		g.velocityY = -g.velocityY
+	if g.recordShown > 0 {
+		g.recordShown--
 	}
-	if g.logoY+g.logoHeight > screenHeight {
-		g.logoY = screenHeight - g.logoHeight
-		g.velocityY = -g.velocityY
+	if g.milestoneShown > 0 {
+		g.milestoneShown--
 	}
+	g.updateBursts()
+	bouncedWall := false
+	g.rotation = math.Mod(g.rotation+g.rotationSpeed*dt, 2*math.Pi)
+	g.hue = math.Mod(g.hue+g.config.RainbowSpeed*dt, 360)
+	p := g.physics()
+	// Drawing interpolates from where the logos are now to where this tick leaves them
+	for i := range g.logos {
+		l := &g.logos[i]
+		l.prevX, l.prevY = l.x, l.y
+		l.squashX, l.squashY = max(l.squashX-1, 0), max(l.squashY-1, 0)
+	}
+	// A tick longer than a reference tick, at a low tick rate, is split into
+	// substeps, so a fast logo can't skip past a wall or another logo
+	n := substeps(dt)
+	for range n {
+		for i := range g.logos {
+			l := &g.logos[i]
+			b := p.advance(l, dt/float64(n))
+			bouncedX, bouncedY := b.x, b.y
 
-	// Check if the logo touches the corner
-	if g.logoX < cornerTolerance || g.logoX > screenWidth-logoWidth-cornerTolerance {
-		if g.logoY < cornerTolerance || g.logoY > screenHeight-g.logoHeight-cornerTolerance {
-			g.cornerHits++
-			g.hitCorner = true
+			// Change the logo color once per bounce, even if two walls were hit
+			if bouncedX || bouncedY {
+				g.nextLogoColor(l)
+				x, y := l.contactPoint(bouncedX, bouncedY, g.width, g.height)
+				g.addBurst(x, y, bouncedX && bouncedY)
+				bouncedWall = bouncedWall || bouncedX != bouncedY
+				if g.wobble > 0 {
+					// Only draw from the seeded source with a wobble, so without
+					// one the colors and start positions stay the same
+					l.wobble((2*g.rng.Float64()-1)*g.wobble, bouncedX, bouncedY)
+				}
+				if jitter := g.config.BounceJitter; jitter > 0 {
+					l.jitterBounce(g.rng.Float64()*jitter, g.rng.Float64()*jitter, bouncedX, bouncedY)
+				}
+			}
+			if bouncedX {
+				g.wallBounces++
+				l.squashX = squashTicks
+			}
+			if bouncedY {
+				g.wallBounces++
+				l.squashY = squashTicks
+			}
+			g.maxSpeedSeen = math.Max(g.maxSpeedSeen, math.Hypot(l.velocityX, l.velocityY))
+
+			// Only count a corner when both walls were hit on the same frame
+			if bouncedX && bouncedY {
+				g.cornerHits[l.corner(g.width, g.height)]++
+				if b.miss <= g.config.PerfectTolerance {
+					g.perfectHits++
+				}
+				g.hitCorner = true
+				g.cornerFlashFrames = g.config.CornerFlashFrames
+				g.shakeFrames = g.config.ShakeFrames
+				g.rotationSpeed = -g.rotationSpeed
+				g.rampSpeed(l)
+				g.lifetimeHits++
+				g.checkRecord()
+				g.flushStats()
+				g.countCombo()
+				g.checkTarget()
+			}
+
+			// The boss logo counts like a wall, but never towards a corner
+			if b.obstacle {
+				g.nextLogoColor(l)
+				g.wallBounces++
+				bouncedWall = true
+			}
 		}
+
+		// Bounce the logos off each other
+		g.collideLogos()
+	}
+	// Count how far the logos actually got, after bounces and pushes, rather
+	// than how far they tried to move
+	for i := range g.logos {
+		l := &g.logos[i]
+		g.distanceTraveled += math.Hypot(l.x-l.prevX, l.y-l.prevY)
+	}
+	g.trackHeatmap()
+	if g.dumpInterval > 0 && g.ticks%g.dumpInterval == 0 {
+		g.dumpState()
 	}
 
-	// Adjust velocity based on mouse input
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		dx := float64(x) - (g.logoX + logoWidth/2)
-		dy := float64(y) - (g.logoY + g.logoHeight/2)
-		g.velocityX += dx * nudgeAmount / 1000
-		g.velocityY += dy * nudgeAmount / 1000
+	// Only chime when a logo first reaches the corner, not on every frame it stays there
+	if g.hitCorner && !wasHitCorner {
+		playSound(g.cornerPlayer)
+	} else if bouncedWall {
+		playSound(g.bouncePlayer)
+	}
+}
 
-		// Clamp velocity to logoMaxVelocity
-		if math.Abs(g.velocityX) > logoMaxVelocity {
-			g.velocityX = math.Copysign(logoMaxVelocity, g.velocityX)
-		}
-		if math.Abs(g.velocityY) > logoMaxVelocity {
-			g.velocityY = math.Copysign(logoMaxVelocity, g.velocityY)
+// tick advances the physics by one step, along with the -trace steering and
+// the sketch that follow it
+func (g *Game) tick() {
+	if g.trace != nil {
+		g.steerTrace()
+	}
+	g.step()
+	g.lastStep = time.Now()
+	if g.sketch {
+		g.sketchPaths()
+	}
+}
+
+// tickSeconds returns how long one Update tick lasts, so movement covers the
+// same distance per second whatever the tick rate is
+func tickSeconds() float64 {
+	tps := ebiten.TPS()
+	if tps <= 0 {
+		// Ticks follow the frame rate, assume the reference rate
+		return 1.0 / referenceTPS
+	}
+	return 1 / float64(tps)
+}
+
+// substeps returns how many physics steps a tick of dt seconds takes, so
+// that none covers more than a reference tick
+func substeps(dt float64) int {
+	// Round off float error first, so exactly one reference tick is one step
+	return max(1, int(math.Ceil(math.Round(dt*referenceTPS*1e6)/1e6)))
+}
+
+// timeStep returns how many seconds the physics advance per tick, which is
+// cut down in slow motion. The elapsed timer keeps running in real time.
+func (g *Game) timeStep() float64 {
+	if g.slowMotion {
+		return tickSeconds() * slowMotionFactor
+	}
+	return tickSeconds()
+}
+
+// flushStats persists the lifetime stats, logging rather than failing on errors
+func (g *Game) flushStats() {
+	if g.statsPath == "" {
+		return
+	}
+	stats := savedStats{LifetimeHits: g.lifetimeHits, BestSession: g.bestSession}
+	if err := saveStats(g.statsPath, stats); err != nil {
+		log.Printf("Could not save stats: %v", err)
+	}
+}
+
+// quit saves the stats and settings when the game ends. Each save is safe to
+// repeat, so quit can run again if Update is called after terminating.
+func (g *Game) quit() {
+	g.saveSettings()
+	g.flushStats()
+	g.flushSessionStats()
+	g.flushHeatmap()
+}
+
+// countCombo extends the combo if the last corner hit was less than the
+// combo window ago, and starts a new one otherwise
+func (g *Game) countCombo() {
+	if g.config.ComboWindow == 0 {
+		return
+	}
+	now := g.elapsed()
+	window := time.Duration(g.config.ComboWindow * float64(time.Second))
+	if g.combo > 0 && now-g.lastCornerAt <= window {
+		g.combo++
+	} else {
+		g.combo = 1
+	}
+	g.lastCornerAt = now
+}
+
+// checkTarget ends a time trial once the corner hits reach the target,
+// stopping the timer at the final time
+func (g *Game) checkTarget() {
+	if g.target == 0 || g.finished || g.totalCornerHits() < g.target {
+		return
+	}
+	g.finishTime = g.elapsed()
+	g.finished = true
+}
+
+// checkRecord raises the best session when the corner hits pass it. The banner
+// only shows when the old best is first beaten, not on every hit after that.
+// Assisted hits aren't legit, so they can't set a record.
+func (g *Game) checkRecord() {
+	hits := g.totalCornerHits()
+	if g.assist || hits <= g.bestSession {
+		return
+	}
+	g.bestSession = hits
+	if !g.newRecord {
+		g.newRecord = true
+		g.recordShown = recordBannerFrames
+	}
+}
+
+// flushSessionStats writes the session summary. It only writes once, as
+// Update may still be called after the game started terminating.
+func (g *Game) flushSessionStats() {
+	if g.sessionFlushed || g.sessionPath == "" {
+		return
+	}
+	g.sessionFlushed = true
+	stats := sessionStats{
+		CornerHits:      g.totalCornerHits(),
+		Corners:         g.cornerHits,
+		PerfectCorners:  g.perfectHits,
+		WallBounces:     g.wallBounces,
+		DurationSeconds: g.elapsed().Seconds(),
+		MaxSpeed:        g.maxSpeedSeen,
+	}
+	if err := saveStats(g.sessionPath, stats); err != nil {
+		log.Printf("Could not save session stats: %v", err)
+	}
+}
+
+// nextLogoColor advances the tint of l on a bounce, never picking its
+// current color again. It does nothing while auto-cycling is off, or in
+// rainbow mode where the color changes all the time.
+func (g *Game) nextLogoColor(l *Logo) {
+	if !g.autoColor || g.rainbow || len(logoPalette) < 2 {
+		return
+	}
+	if g.randomColors {
+		// Pick among all other colors by skipping over the current index
+		next := g.rng.Intn(len(logoPalette) - 1)
+		if next >= l.colorIndex {
+			next++
 		}
+		l.colorIndex = next
+		return
 	}
+	l.colorIndex = (l.colorIndex + 1) % len(logoPalette)
+}
 
-	return nil
+// stepLogoColors moves the tint of every logo delta places through the
+// palette, wrapping around at either end
+func (g *Game) stepLogoColors(delta int) {
+	for i := range g.logos {
+		l := &g.logos[i]
+		l.colorIndex = ((l.colorIndex+delta)%len(logoPalette) + len(logoPalette)) % len(logoPalette)
+	}
 }
 
-func (g *Game) handleKeyPresses() {
-	// Check for escape key press to toggle pause state
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
-		if !g.keyState[ebiten.KeyEscape] {
-			g.paused = !g.paused
+// keyJustPressed reports whether key went down this frame, debounced via keyState
+func (g *Game) keyJustPressed(key ebiten.Key) bool {
+	pressed := g.input.isKeyPressed(key)
+	wasPressed := g.keyState[key]
+	g.keyState[key] = pressed
+	return pressed && !wasPressed
+}
+
+// arrowKeyNudge returns the velocity change per tick from the held arrow keys.
+// Releasing the keys just stops the nudge, the logo keeps its new velocity.
+func (g *Game) arrowKeyNudge() (float64, float64) {
+	var x, y float64
+	if g.input.isKeyPressed(ebiten.KeyArrowLeft) {
+		x -= keyNudgeAmount
+	}
+	if g.input.isKeyPressed(ebiten.KeyArrowRight) {
+		x += keyNudgeAmount
+	}
+	if g.input.isKeyPressed(ebiten.KeyArrowUp) {
+		y -= keyNudgeAmount
+	}
+	if g.input.isKeyPressed(ebiten.KeyArrowDown) {
+		y += keyNudgeAmount
+	}
+	return x, y
+}
+
+// anyKeyJustPressed is like keyJustPressed for several keys bound to the same action
+func (g *Game) anyKeyJustPressed(keys ...ebiten.Key) bool {
+	pressed := false
+	for _, key := range keys {
+		// Check every key, so none of them misses its keyState update
+		if g.keyJustPressed(key) {
+			pressed = true
 		}
-		g.keyState[ebiten.KeyEscape] = true
-	} else {
-		g.keyState[ebiten.KeyEscape] = false
 	}
+	return pressed
+}
 
-	if g.paused {
-		// Check for 'C' to continue
-		if ebiten.IsKeyPressed(ebiten.KeyC) {
-			if !g.keyState[ebiten.KeyC] {
-				g.paused = false
-			}
-			g.keyState[ebiten.KeyC] = true
+func (g *Game) handleKeyPresses() {
+	// Check for the pause key, Escape by default, to toggle pause state
+	if g.keyJustPressed(g.keys[actionPause]) {
+		g.setPaused(!g.paused)
+	}
+
+	// Check for the fullscreen key, 'F' by default
+	if g.keyJustPressed(g.keys[actionFullscreen]) {
+		ebiten.SetFullscreen(!ebiten.IsFullscreen())
+	}
+
+	// Check for the dump key, 'P' by default, to print the state to stdout
+	if g.keyJustPressed(g.keys[actionDump]) {
+		g.dumpState()
+	}
+
+	// Check for 'T' to toggle slow motion
+	if g.keyJustPressed(ebiten.KeyT) {
+		g.slowMotion = !g.slowMotion
+	}
+
+	// Check for 'H' to toggle the on-screen hits and timer
+	if g.keyJustPressed(ebiten.KeyH) {
+		g.showHUD = !g.showHUD
+	}
+
+	// Check for 'K' to toggle the large clock
+	if g.keyJustPressed(ebiten.KeyK) {
+		g.showClock = !g.showClock
+	}
+
+	// Check for 'Z' to cycle through the preset screen sizes
+	if g.keyJustPressed(ebiten.KeyZ) {
+		g.screenPreset = (g.screenPreset + 1) % (len(screenPresets) + 1)
+	}
+
+	// Check for 'N' to toggle the minimap
+	if g.keyJustPressed(ebiten.KeyN) {
+		g.showMap = !g.showMap
+	}
+
+	// Check for 'V' to toggle following the first logo
+	if g.keyJustPressed(ebiten.KeyV) {
+		g.follow = !g.follow
+	}
+
+	// Check for F12 to take a screenshot
+	if g.keyJustPressed(ebiten.KeyF12) {
+		if inBrowser {
+			log.Printf("Screenshots can't be saved in the browser, use its own screenshot tool")
 		} else {
-			g.keyState[ebiten.KeyC] = false
+			g.screenshot = true
+		}
+	}
+
+	// Check for 'G' to start or stop recording a GIF
+	if g.keyJustPressed(ebiten.KeyG) {
+		g.toggleRecording()
+	}
+
+	// Check for 'E' to toggle Etch-a-Sketch mode and 'X' to wipe the sketch
+	if g.keyJustPressed(ebiten.KeyE) {
+		g.sketch = !g.sketch
+	}
+	if g.keyJustPressed(ebiten.KeyX) {
+		g.clearSketch()
+	}
+
+	// Check for '[' and ']' to step the logo color and 'A' to toggle changing it on bounces
+	if g.keyJustPressed(ebiten.KeyBracketLeft) {
+		g.stepLogoColors(-1)
+	}
+	if g.keyJustPressed(ebiten.KeyBracketRight) {
+		g.stepLogoColors(1)
+	}
+	if g.keyJustPressed(ebiten.KeyA) {
+		g.autoColor = !g.autoColor
+	}
+
+	// Check for 'L' to switch to the next logo
+	if g.keyJustPressed(ebiten.KeyL) {
+		g.nextLogo()
+	}
+
+	// Check for 'D' to toggle the debug overlay
+	if g.keyJustPressed(ebiten.KeyD) {
+		g.showDebug = !g.showDebug
+	}
+
+	// Check for 'M' to mute and '+'/'-' to change the volume
+	if g.keyJustPressed(ebiten.KeyM) {
+		g.muted = !g.muted
+	}
+
+	// Check for 'B' to mute just the wall bounce tick
+	if g.keyJustPressed(ebiten.KeyB) {
+		g.bounceMuted = !g.bounceMuted
+	}
+	if g.anyKeyJustPressed(ebiten.KeyEqual, ebiten.KeyNumpadAdd) {
+		g.changeVolume(volumeStep)
+	}
+	if g.anyKeyJustPressed(ebiten.KeyMinus, ebiten.KeyNumpadSubtract) {
+		g.changeVolume(-volumeStep)
+	}
+
+	// Check for Space to teleport the logos, unless an action was remapped to it
+	if !g.isActionKey(ebiten.KeySpace) && g.keyJustPressed(ebiten.KeySpace) {
+		g.teleport()
+	}
+
+	// Check for the restart key, 'R' by default, which also closes the pause menu
+	if g.keyJustPressed(g.keys[actionRestart]) {
+		g.reset()
+		g.setPaused(false)
+	}
+
+	if g.paused || g.finished {
+		// Check for the continue key, 'C' by default
+		if g.keyJustPressed(g.keys[actionContinue]) {
+			g.setPaused(false)
 		}
 
-		// Check for 'Q' to quit
-		if ebiten.IsKeyPressed(ebiten.KeyQ) {
+		// Check for 'S' to open or close the settings, where the arrow keys change them
+		if g.paused && g.keyJustPressed(ebiten.KeyS) {
+			g.inSettings = !g.inSettings
+		}
+		if g.inSettings {
+			g.handleSettingsKeys()
+		}
+
+		// Check for '.' to advance a single tick while paused
+		if g.paused && g.keyJustPressed(ebiten.KeyPeriod) {
+			g.stepRequested = true
+		}
+
+		// Check for the quit key, 'Q' by default
+		if g.input.isKeyPressed(g.keys[actionQuit]) {
 			g.terminated = true
-			g.keyState[ebiten.KeyQ] = true
-		} else {
-			g.keyState[ebiten.KeyQ] = false
+		}
+	} else {
+		// Check for page up/down to change the speed
+		if g.keyJustPressed(ebiten.KeyPageUp) {
+			g.scaleSpeed(speedUpFactor)
+		}
+		if g.keyJustPressed(ebiten.KeyPageDown) {
+			g.scaleSpeed(slowDownFactor)
 		}
 	}
 }
 
+// reset starts a new session: the hits and timer go back to zero and every
+// logo gets a fresh random position and direction
+func (g *Game) reset() {
+	g.cornerHits = [4]int{}
+	g.perfectHits = 0
+	g.ticks = 0
+	g.combo = 0
+	g.finished = false
+	g.wallBounces = 0
+	g.distanceTraveled = 0
+	g.maxSpeedSeen = 0
+	g.hitCorner = false
+	g.cornerFlashFrames = 0
+	g.shakeFrames = 0
+	g.newRecord = false
+	g.recordShown = 0
+	g.milestones = 0
+	g.milestoneShown = 0
+	g.bursts = nil
+	g.clearSketch()
+	if g.trace != nil {
+		g.trace.next, g.trace.hold = 0, 0
+	}
+	g.startTime = time.Now()
+	g.pausedAt = g.startTime
+	g.pausedTotal = 0
+	g.maxVelocityX, g.maxVelocityY = g.config.maxVelocities()
+	g.rotation = 0
+	g.rotationSpeed = g.config.RotationSpeed
+	for i := range g.logos {
+		g.logos[i] = g.spawnLogo(i)
+	}
+	// The random position is still drawn for both axes, so -seed picks the same
+	// direction and colors whether or not -x/-y are given
+	if g.startX != nil {
+		g.logos[0].x = *g.startX
+	}
+	if g.startY != nil {
+		g.logos[0].y = *g.startY
+	}
+	for i := range g.logos {
+		g.logos[i].jumped()
+	}
+}
+
+// setPaused pauses or resumes the game, keeping track of the time spent paused
+func (g *Game) setPaused(paused bool) {
+	if paused == g.paused {
+		return
+	}
+	if paused {
+		g.pausedAt = time.Now()
+	} else {
+		g.pausedTotal += time.Since(g.pausedAt)
+		// Continuing closes the settings and keeps what was changed there
+		g.inSettings = false
+		g.saveSettings()
+	}
+	g.paused = paused
+}
+
+// setHidden blanks the screen or shows the game again. Hiding pauses the
+// timer and physics, and showing the game brings back the pause state from
+// before, so a game hidden while running runs on.
+func (g *Game) setHidden(hidden bool) {
+	if hidden == g.hidden {
+		return
+	}
+	if hidden {
+		g.hidePaused = g.paused
+		g.setPaused(true)
+	} else {
+		g.setPaused(g.hidePaused)
+		// Bring the hits and time back to the title right away
+		g.titleUpdatedAt = time.Time{}
+	}
+	g.hidden = hidden
+}
+
+// elapsed returns the time played so far, excluding time spent paused
+func (g *Game) elapsed() time.Duration {
+	// Read the clock once, so a pause right at the start comes out at exactly zero
+	now := time.Now()
+	if g.finished {
+		return g.finishTime
+	}
+	elapsed := now.Sub(g.startTime) - g.pausedTotal
+	if g.paused {
+		elapsed -= now.Sub(g.pausedAt)
+	}
+	return elapsed
+}
+
+// scaleSpeed multiplies the speed and the velocity caps by factor, keeping the
+// direction of travel and the caps within logoMinVelocity and logoVelocityCeiling
+func (g *Game) scaleSpeed(factor float64) {
+	clamp := func(v float64) float64 {
+		return math.Max(logoMinVelocity, math.Min(v, logoVelocityCeiling))
+	}
+	// Both caps change by the factor of the one that runs out of range first,
+	// so they keep their ratio
+	factorX := clamp(g.maxVelocityX*factor) / g.maxVelocityX
+	factorY := clamp(g.maxVelocityY*factor) / g.maxVelocityY
+	if math.Abs(factorX-1) < math.Abs(factorY-1) {
+		factor = factorX
+	} else {
+		factor = factorY
+	}
+	g.maxVelocityX *= factor
+	g.maxVelocityY *= factor
+	for i := range g.logos {
+		g.logos[i].velocityX *= factor
+		g.logos[i].velocityY *= factor
+	}
+}
+
+// rampSpeed speeds l up after a corner hit, until a velocity component reaches
+// the configured cap. The velocity caps for nudging grow along, so nudging
+// doesn't brake a logo that was sped up. The cap stays below
+// logoVelocityCeiling, where reflecting the overshoot keeps the corner
+// detection exact however far a single step goes past the walls.
+func (g *Game) rampSpeed(l *Logo) {
+	speed := math.Max(math.Abs(l.velocityX), math.Abs(l.velocityY))
+	if speed == 0 {
+		return
+	}
+	factor := math.Max(1, math.Min(g.config.CornerSpeedUp, g.config.CornerSpeedCap/speed))
+	l.velocityX *= factor
+	l.velocityY *= factor
+	g.maxVelocityX = math.Max(g.maxVelocityX, math.Abs(l.velocityX))
+	g.maxVelocityY = math.Max(g.maxVelocityY, math.Abs(l.velocityY))
+}
+
+// Layout follows the size of the window or of the area the game is drawn to
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return screenWidth, screenHeight
+	// Use the real window size so the borders follow resizes and fullscreen,
+	// unless a replay has the size it was recorded at
+	width, height := g.screenSize(outsideWidth, outsideHeight)
+	g.resize(width, height)
+	return width, height
 }
 
+// screenSize returns the size to lay the screen out at for a window of the
+// given size, which is the preset picked with Z if there is one
+func (g *Game) screenSize(outsideWidth, outsideHeight int) (int, int) {
+	width, height := g.input.screenSize(outsideWidth, outsideHeight)
+	if g.screenPreset > 0 {
+		p := screenPresets[g.screenPreset-1]
+		return p.X, p.Y
+	}
+	return width, height
+}
+
+// resize moves the borders to a new screen size, inside the margins, and
+// pulls the logos back inside
+func (g *Game) resize(width, height int) {
+	g.viewWidth, g.viewHeight = float64(width), float64(height)
+	innerWidth, innerHeight := g.config.innerSize(width, height)
+	// A window squeezed below the margins leaves no room at all
+	innerWidth, innerHeight = max(innerWidth, 0), max(innerHeight, 0)
+	if float64(innerWidth) == g.width && float64(innerHeight) == g.height {
+		return
+	}
+	g.width = float64(innerWidth)
+	g.height = float64(innerHeight)
+	for i := range g.logos {
+		g.logos[i].clampTo(g.width, g.height)
+	}
+	g.centerObstacle()
+}
+
+// Draw renders the logos and overlays onto screen
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Set the background color
-	if g.hitCorner {
-		screen.Fill(color.RGBA{0, 255, 0, 255}) // Flash green if hit a corner
-	} else {
-		screen.Fill(color.RGBA{0, 0, 255, 255}) // Default blue background
+	if g.hidden {
+		// Nothing gives the game away, not even the window title
+		screen.Fill(hiddenColor)
+		if g.title != hiddenTitle {
+			g.title = hiddenTitle
+			ebiten.SetWindowTitle(hiddenTitle)
+		}
+		return
 	}
 
-	// Draw the logo
-	op := &ebiten.DrawImageOptions{}
-	scale := logoWidth / float64(g.logoImage.Bounds().Dx())
-	op.GeoM.Scale(scale, scale)
-	op.GeoM.Translate(g.logoX, g.logoY)
-	screen.DrawImage(g.logoImage, op)
+	g.drawComposited(screen, g.drawScene)
+
+	if g.recording != nil {
+		g.captureFrame(screen)
+	}
+
+	if g.screenshot {
+		g.screenshot = false
+		takeScreenshot(screen)
+	}
+}
+
+// drawScene draws everything on the screen, the logos as well as the HUD
+// and the menus, to be shaken or inverted as a whole after corner hits
+func (g *Game) drawScene(screen *ebiten.Image) {
+	// Set the background, flashing and fading back after a corner hit
+	camX, camY := g.cameraOffset()
+	g.drawBackground(screen, -camX, -camY)
+	if g.follow || g.config.hasMargins() {
+		g.drawMovedWorld(screen, camX, camY)
+	} else {
+		g.drawWorld(screen)
+	}
 
 	// Update window title with corner hits and elapsed time
 	g.updateWindowTitle()
 
-	if g.paused {
+	if g.showHUD {
+		g.drawHUD(screen)
+	}
+
+	if g.showClock {
+		g.drawClock(screen)
+	}
+
+	if g.showMap {
+		g.drawMinimap(screen)
+	}
+
+	if g.recordShown > 0 {
+		g.drawRecordBanner(screen)
+	}
+
+	if g.milestoneShown > 0 {
+		g.drawMilestone(screen)
+	}
+
+	if g.showDebug {
+		g.drawDebug(screen)
+	}
+
+	// A pause for the window losing focus shows no menu, just the frozen game
+	if g.finished && (!g.paused || g.focusPaused) {
+		g.drawResults(screen)
+	}
+
+	if g.paused && g.inSettings {
+		g.drawSettings(screen)
+	} else if g.paused && !g.focusPaused {
 		g.drawPauseMenu(screen)
 	}
 }
 
+// drawWorld draws everything that moves with the logos, in the same
+// coordinates the physics run in
+func (g *Game) drawWorld(screen *ebiten.Image) {
+	// The sketched paths go under everything else
+	if g.sketch && g.canvas != nil {
+		screen.DrawImage(g.canvas, nil)
+	}
+
+	// Draw the trails first so no logo is covered by another one's trail
+	for i := range g.logos {
+		g.drawTrail(screen, &g.logos[i])
+	}
+	if g.obstacle != nil {
+		g.drawObstacle(screen)
+	}
+	t := g.interpolation()
+	for i := range g.logos {
+		x, y := g.logos[i].interpolated(t)
+		g.drawWrapped(screen, &g.logos[i], x, y, 1)
+	}
+	g.drawBursts(screen)
+
+	if g.showDebug {
+		g.drawPredictions(screen)
+	}
+}
+
+// interpolation returns how far into the current tick this frame is, from 0
+// to 1, so the logos move smoothly even when frames are drawn more often
+// than the physics tick
+func (g *Game) interpolation() float64 {
+	if ebiten.TPS() <= 0 || g.paused || g.finished {
+		// Ticks follow frames, or nothing moves, so there is nothing in between
+		return 1
+	}
+	return math.Min(1, time.Since(g.lastStep).Seconds()/tickSeconds())
+}
+
+// lerpColor blends from a to b, with t = 0 giving a and t = 1 giving b
+func lerpColor(a, b color.RGBA, t float64) color.RGBA {
+	lerp := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t))
+	}
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), lerp(a.A, b.A)}
+}
+
+// drawLogo draws l with its tint at x, y with the given opacity
+func (g *Game) drawLogo(screen *ebiten.Image, l *Logo, x, y float64, alpha float32) {
+	op := &ebiten.DrawImageOptions{}
+	frame := g.logoFrame()
+	op.GeoM.Scale(g.logoScale, g.logoScale)
+	// Rotate around the center of the scaled logo, then move it into place,
+	// with the hitbox at x, y
+	w, h := g.drawnLogoSize()
+	op.GeoM.Translate(-w/2, -h/2)
+	op.GeoM.Rotate(g.rotation)
+	// Squash along the axis of a wall bounce, around the center so the logo stays in place
+	op.GeoM.Scale(l.squashScale(g.config.Squash))
+	op.GeoM.Translate(x-float64(g.hitbox.Min.X)*g.logoScale+w/2, y-float64(g.hitbox.Min.Y)*g.logoScale+h/2)
+	op.ColorScale.ScaleWithColor(g.logoColor(l))
+	op.ColorScale.ScaleAlpha(alpha)
+	op.Filter = g.logoFilter
+	screen.DrawImage(frame, op)
+}
+
+// updateWindowTitle shows the hits and elapsed time in the window title. It
+// only changes the title every titleInterval, unless the hits changed, as
+// setting it on every frame is slow and flickers on some platforms.
 func (g *Game) updateWindowTitle() {
-	elapsedTime := time.Since(g.startTime)
-	hours := int(elapsedTime.Hours())
-	minutes := int(elapsedTime.Minutes()) % 60
-	seconds := int(elapsedTime.Seconds()) % 60
-	milliseconds := int(elapsedTime.Milliseconds()) % 1000
-	title := fmt.Sprintf("Hits: %d | Time: %02d:%02d:%02d.%02d", g.cornerHits, hours, minutes, seconds, milliseconds/10)
+	now := time.Now()
+	hits := g.totalCornerHits()
+	if hits == g.titleHits && now.Sub(g.titleUpdatedAt) < titleInterval {
+		return
+	}
+	// Round the time to the interval, so it counts up in even steps
+	elapsed := g.elapsed().Truncate(titleInterval)
+	title := fmt.Sprintf("Hits: %d | Perfect: %d | Lifetime: %d | Bounces: %d | Distance: %s | Time: %s",
+		hits, g.perfectHits, g.lifetimeHits, g.wallBounces, formatDistance(g.distanceTraveled), formatElapsed(elapsed))
+	if g.assist {
+		title += " | Assisted"
+	}
+	if g.wrap {
+		title += " | Wrap"
+	}
+	g.titleHits = hits
+	g.titleUpdatedAt = now
+	if title == g.title {
+		return
+	}
+	g.title = title
 	ebiten.SetWindowTitle(title)
 }
 
+// formatElapsed formats d as HH:MM:SS.hh
+func formatElapsed(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+	milliseconds := int(d.Milliseconds()) % 1000
+	return fmt.Sprintf("%02d:%02d:%02d.%02d", hours, minutes, seconds, milliseconds/10)
+}
+
+// drawDebug prints the frame rates and logo states in the top-left corner
+func (g *Game) drawDebug(screen *ebiten.Image) {
+	msg := fmt.Sprintf("FPS: %0.2f\nTPS: %0.2f", ebiten.ActualFPS(), ebiten.ActualTPS())
+	for _, l := range g.logos {
+		msg += fmt.Sprintf("\nPos: %0.1f, %0.1f Vel: %0.2f, %0.2f", l.x, l.y, l.velocityX, l.velocityY)
+	}
+	ebitenutil.DebugPrint(screen, msg)
+}
+
+// drawPredictions draws where the logos will be ghostTicks ticks from now,
+// and an arrow along the velocity of each, for the debug overlay
+func (g *Game) drawPredictions(screen *ebiten.Image) {
+	// Faint ghosts show where the logos are headed
+	p := g.physics()
+	for i := range g.logos {
+		ghost := p.predict(g.logos[i], ghostTicks, g.timeStep())
+		ghost.squashX, ghost.squashY = 0, 0 // Sprung back by then
+		g.drawLogo(screen, &ghost, ghost.x, ghost.y, ghostAlpha)
+	}
+
+	// Arrows show the velocity of each logo, from where it's drawn this frame
+	t := g.interpolation()
+	for i := range g.logos {
+		l := &g.logos[i]
+		x, y := l.interpolated(t)
+		g.drawVelocity(screen, l, x+l.width/2, y+l.height/2)
+	}
+}
+
+// velocityColor is the color of the debug velocity arrows, standing out from
+// the logo palette
+var velocityColor = color.RGBA{255, 128, 0, 255}
+
+// hiddenColor is the plain gray the boss key fills the screen with
+var hiddenColor = color.RGBA{192, 192, 192, 255}
+
+// drawVelocity draws an arrow from x, y along the velocity of l, as long as
+// the distance l covers in velocityArrowScale ticks
+func (g *Game) drawVelocity(screen *ebiten.Image, l *Logo, x, y float64) {
+	tipX := x + l.velocityX*velocityArrowScale
+	tipY := y + l.velocityY*velocityArrowScale
+	vector.StrokeLine(screen, float32(x), float32(y), float32(tipX), float32(tipY), velocityArrowWidth, velocityColor, true)
+
+	// Arrowhead, two short strokes angled back from the tip
+	angle := math.Atan2(l.velocityY, l.velocityX)
+	for _, side := range []float64{-1, 1} {
+		a := angle + math.Pi - side*math.Pi/6
+		vector.StrokeLine(screen, float32(tipX), float32(tipY),
+			float32(tipX+velocityArrowHead*math.Cos(a)), float32(tipY+velocityArrowHead*math.Sin(a)),
+			velocityArrowWidth, velocityColor, true)
+	}
+}
+
 func (g *Game) drawPauseMenu(screen *ebiten.Image) {
+	g.drawMenu(screen, []string{
+		"PAUSED",
+		fmt.Sprintf("[%s] Continue", g.keys[actionContinue]),
+		fmt.Sprintf("[%s] Restart", g.keys[actionRestart]),
+		fmt.Sprintf("[%s] Quit", g.keys[actionQuit]),
+		"[S] Settings",
+		"[.] Step one tick",
+	}, -1)
+}
+
+// drawResults shows the time it took to reach the -target corner hits
+func (g *Game) drawResults(screen *ebiten.Image) {
+	g.drawMenu(screen, []string{
+		"FINISHED",
+		fmt.Sprintf("%d corners in %s", g.target, formatElapsed(g.finishTime)),
+		fmt.Sprintf("[%s] Restart", g.keys[actionRestart]),
+		fmt.Sprintf("[%s] Quit", g.keys[actionQuit]),
+	}, -1)
+}
+
+// drawMenu draws a box in the middle of the screen with the lines centered on
+// it, highlighting the line at index highlight unless it's -1
+func (g *Game) drawMenu(screen *ebiten.Image, lines []string, highlight int) {
 	// Draw the pause menu background
-	pauseMenuWidth := 300
-	pauseMenuHeight := 200
-	pauseMenuX := (screenWidth - pauseMenuWidth) / 2
-	pauseMenuY := (screenHeight - pauseMenuHeight) / 2
-	ebitenutil.DrawRect(screen, float64(pauseMenuX), float64(pauseMenuY), float64(pauseMenuWidth), float64(pauseMenuHeight), color.RGBA{0, 0, 128, 255}) // Dark blue background
+	pauseMenuWidth := 300.0
+	pauseMenuHeight := 250.0
+	pauseMenuX := (g.viewWidth - pauseMenuWidth) / 2
+	pauseMenuY := (g.viewHeight - pauseMenuHeight) / 2
+	ebitenutil.DrawRect(screen, pauseMenuX, pauseMenuY, pauseMenuWidth, pauseMenuHeight, color.RGBA{0, 0, 128, 255}) // Dark blue background
 
 	// Draw the pause menu border
 	borderThickness := 2.0
-	ebitenutil.DrawRect(screen, float64(pauseMenuX), float64(pauseMenuY), float64(pauseMenuWidth), borderThickness, color.White)
-	ebitenutil.DrawRect(screen, float64(pauseMenuX), float64(pauseMenuY), borderThickness, float64(pauseMenuHeight), color.White)
-	ebitenutil.DrawRect(screen, float64(pauseMenuX), float64(pauseMenuY+pauseMenuHeight)-borderThickness, float64(pauseMenuWidth), borderThickness, color.White)
-	ebitenutil.DrawRect(screen, float64(pauseMenuX+pauseMenuWidth)-borderThickness, float64(pauseMenuY), borderThickness, float64(pauseMenuHeight), color.White)
+	ebitenutil.DrawRect(screen, pauseMenuX, pauseMenuY, pauseMenuWidth, borderThickness, color.White)
+	ebitenutil.DrawRect(screen, pauseMenuX, pauseMenuY, borderThickness, pauseMenuHeight, color.White)
+	ebitenutil.DrawRect(screen, pauseMenuX, pauseMenuY+pauseMenuHeight-borderThickness, pauseMenuWidth, borderThickness, color.White)
+	ebitenutil.DrawRect(screen, pauseMenuX+pauseMenuWidth-borderThickness, pauseMenuY, borderThickness, pauseMenuHeight, color.White)
 
-	// Draw the pause menu text
-	pauseText := "PAUSED"
-	continueText := "[C]ontinue"
-	quitText := "[Q]uit"
-	text.Draw(screen, pauseText, basicfont.Face7x13, pauseMenuX+pauseMenuWidth/2-len(pauseText)*7/2, pauseMenuY+50, color.White)
-	text.Draw(screen, continueText, basicfont.Face7x13, pauseMenuX+pauseMenuWidth/2-len(continueText)*7/2, pauseMenuY+100, color.White)
-	text.Draw(screen, quitText, basicfont.Face7x13, pauseMenuX+pauseMenuWidth/2-len(quitText)*7/2, pauseMenuY+150, color.White)
+	// Draw the pause menu text, centered using the width of the font.
+	// Longer menus squeeze the lines closer together to fit.
+	spacing := 50.0
+	if len(lines) > 1 {
+		spacing = math.Min(spacing, 150/float64(len(lines)-1))
+	}
+	for i, line := range lines {
+		y := pauseMenuY + 50 + spacing*float64(i)
+		if i == highlight {
+			ebitenutil.DrawRect(screen, pauseMenuX+10, y-(hudLineHeight-2), pauseMenuWidth-20, hudLineHeight+2, color.RGBA{64, 64, 192, 255})
+		}
+		x := pauseMenuX + pauseMenuWidth/2 - g.textWidth(line)/2
+		g.drawText(screen, line, x, y, color.White)
+	}
 }
 
-func main() {
-	ebiten.SetWindowSize(screenWidth, screenHeight)
-	ebiten.SetWindowTitle("DVD Logo Bouncer")
-
-	logoImage, _, err := ebitenutil.NewImageFromReader(bytes.NewReader(logoImageData))
-	if err != nil {
-		log.Fatal(err)
+// randomSign returns 1 or -1 with equal probability
+func randomSign(rng *rand.Rand) float64 {
+	if rng.Intn(2) == 0 {
+		return -1
 	}
+	return 1
+}
 
-	scale := logoWidth / float64(logoImage.Bounds().Dx())
-	logoHeight := scale * float64(logoImage.Bounds().Dy())
-
-	game := &Game{
-		logoX:      float64(rand.Intn(screenWidth - int(logoWidth))),
-		logoY:      float64(rand.Intn(screenHeight - int(logoHeight))),
-		velocityX:  logoStartVelocity,
-		velocityY:  logoStartVelocity,
-		startTime:  time.Now(),
-		logoImage:  logoImage,
-		logoHeight: logoHeight,
-		keyState:   make(map[ebiten.Key]bool),
+// spawnLogo creates the i-th logo at a random position, heading in a random
+// diagonal direction. Each logo starts on its own color, so they can be told
+// apart until there are more logos than colors.
+func (g *Game) spawnLogo(i int) Logo {
+	return Logo{
+		x:          float64(g.rng.Intn(max(1, int(g.width-g.logoWidth)))),
+		y:          float64(g.rng.Intn(max(1, int(g.height-g.logoHeight)))),
+		velocityX:  randomSign(g.rng) * g.config.LogoStartVelocity,
+		velocityY:  randomSign(g.rng) * g.config.LogoStartVelocity,
+		width:      g.logoWidth,
+		height:     g.logoHeight,
+		colorIndex: i % len(logoPalette),
 	}
+}
 
-	if err := ebiten.RunGame(game); err != nil {
-		panic(err)
+// teleport moves every logo to a random spot, keeping its velocity. The spot
+// is far enough from the walls that the next tick can't reach one, so the
+// landing never scores a corner.
+func (g *Game) teleport() {
+	for i := range g.logos {
+		l := &g.logos[i]
+		margin := math.Hypot(l.velocityX, l.velocityY)*g.timeStep()*referenceTPS + g.config.CornerTolerance + 1
+		l.x = margin + float64(g.rng.Intn(max(1, int(g.width-l.width-2*margin))))
+		l.y = margin + float64(g.rng.Intn(max(1, int(g.height-l.height-2*margin))))
+		// The old positions no longer connect to the new spot
+		l.trail.clear()
+		l.jumped()
 	}
 }
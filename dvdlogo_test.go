@@ -0,0 +1,212 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// gameLoopRunning is set while the tests run inside the game loop, which
+// reading back drawn pixels needs
+var gameLoopRunning bool
+
+// testLoop is a game that runs the tests on its first tick
+type testLoop struct {
+	m    *testing.M
+	code int
+}
+
+func (l *testLoop) Update() error {
+	gameLoopRunning = true
+	l.code = l.m.Run()
+	return ebiten.Termination
+}
+
+func (*testLoop) Draw(*ebiten.Image) {}
+
+func (*testLoop) Layout(int, int) (int, int) { return testWidth, testHeight }
+
+// hasDisplay reports whether there's a display for the game loop to open its
+// window on. Node.js, where the WebAssembly tests run, has no WebGL.
+func hasDisplay() bool {
+	switch runtime.GOOS {
+	case "js":
+		return false
+	case "linux", "freebsd", "netbsd", "openbsd":
+		return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+	}
+	return true
+}
+
+// TestMain runs the tests inside the game loop when there's a display, and
+// without it otherwise, skipping the tests that read back drawn pixels
+func TestMain(m *testing.M) {
+	if !hasDisplay() {
+		os.Exit(m.Run())
+	}
+	loop := &testLoop{m: m, code: 1}
+	if err := ebiten.RunGame(loop); err != nil {
+		panic(err)
+	}
+	os.Exit(loop.code)
+}
+
+// readJSON decodes the JSON file at path into v
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQuitSavesStats(t *testing.T) {
+	running := inputFrame{Width: testWidth, Height: testHeight}
+	paused := running
+	paused.Keys = []ebiten.Key{ebiten.KeyEscape}
+	quitKey := running
+	quitKey.Keys = []ebiten.Key{ebiten.KeyQ}
+	closed := running
+	closed.Closed = true
+
+	tests := []struct {
+		name string
+		last []inputFrame // Frames ending the session
+	}{
+		{"quit key", []inputFrame{paused, running, quitKey}},
+		{"window closed", []inputFrame{closed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := DefaultOptions()
+			opts.StatsPath = filepath.Join(dir, "stats.json")
+			opts.SessionPath = filepath.Join(dir, "session.json")
+			frames := make([]inputFrame, 60)
+			for i := range frames {
+				frames[i] = running
+			}
+			// The session must end on its last frame, not by running out of them
+			frames = append(frames, tt.last...)
+			frames = append(frames, running)
+			opts.Replay = &Replay{frames: frames}
+			g := NewGame(opts)
+			// Head into the top-left corner, reached within the first frames
+			g.logos[0].x, g.logos[0].y, g.logos[0].velocityX, g.logos[0].velocityY = 20, 20, -2, -2
+
+			ticks := 0
+			for g.Update() == nil {
+				ticks++
+			}
+			if ticks != len(frames)-2 {
+				t.Fatalf("ended after %d ticks, want %d", ticks, len(frames)-2)
+			}
+			// Closing the window after quitting with the key saves again, which
+			// must not change what was saved
+			g.quit()
+
+			var stats savedStats
+			readJSON(t, opts.StatsPath, &stats)
+			if stats.LifetimeHits != 1 || stats.BestSession != 1 {
+				t.Errorf("saved stats %+v, want 1 lifetime hit and a best session of 1", stats)
+			}
+			var session sessionStats
+			readJSON(t, opts.SessionPath, &session)
+			if session.CornerHits != 1 || session.Corners[0] != 1 || session.WallBounces != g.wallBounces {
+				t.Errorf("saved session %+v, want 1 top-left corner hit and %d wall bounces", session, g.wallBounces)
+			}
+		})
+	}
+}
+
+func TestBounceJitterReachesCorners(t *testing.T) {
+	// On this screen the logo starting at 0, 60 runs a short loop that
+	// never reaches a corner when reflected exactly, as long as it doesn't spin
+	run := func(jitter float64) int {
+		opts := DefaultOptions()
+		opts.Seed = 1
+		opts.RotationSpeed = 0
+		opts.ScreenHeight = 403
+		opts.LogoWidth = 320
+		opts.BounceJitter = jitter
+		x, y := 0.0, 60.0
+		opts.StartX, opts.StartY = &x, &y
+		g := NewGame(opts)
+		g.RunHeadless(300000)
+		return g.totalCornerHits()
+	}
+	if hits := run(0); hits != 0 {
+		t.Fatalf("%d corner hits without jitter, the path isn't the periodic one", hits)
+	}
+	if hits := run(0.3); hits == 0 {
+		t.Error("no corner hits with jitter")
+	}
+}
+
+func TestDrawLogoKeepsTransparency(t *testing.T) {
+	if !gameLoopRunning {
+		t.Skip("reading back drawn pixels needs the game loop, which needs a display")
+	}
+	// An opaque left third, a half transparent middle and a transparent right
+	// third. Decoded PNGs keep the color of transparent pixels, so they're
+	// white here rather than black, which must not show through the tint.
+	logo := image.NewNRGBA(image.Rect(0, 0, 30, 10))
+	for y := range 10 {
+		for x := range 30 {
+			alpha := uint8(255)
+			switch {
+			case x >= 20:
+				alpha = 0
+			case x >= 10:
+				alpha = 128
+			}
+			logo.SetNRGBA(x, y, color.NRGBA{255, 255, 255, alpha})
+		}
+	}
+	path := filepath.Join(t.TempDir(), "logo.png")
+	if err := writePNG(path, logo); err != nil {
+		t.Fatal(err)
+	}
+	img, err := LoadLogo(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := DefaultOptions()
+	opts.Logo = img
+	opts.LogoWidth = 30 // Drawn at its own size, one pixel per pixel
+	g := NewGame(opts)
+	g.logos[0].colorIndex = 1 // Red
+	screen := ebiten.NewImage(50, 30)
+	screen.Clear()
+	g.drawLogo(screen, &g.logos[0], 10, 10, 1)
+
+	tests := []struct {
+		name  string
+		x     int
+		alpha uint8
+	}{
+		{"opaque", 15, 255},
+		{"half transparent", 25, 128},
+		{"transparent", 35, 0},
+		{"outside the logo", 45, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Ebiten reads back premultiplied colors, so no channel may exceed the alpha
+			c := screen.At(tt.x, 15).(color.RGBA)
+			if d := int(c.A) - int(tt.alpha); d < -1 || d > 1 || c.R > c.A || c.G != 0 || c.B != 0 {
+				t.Errorf("pixel %d, 15 is %v, want red with alpha %d", tt.x, c, tt.alpha)
+			}
+		})
+	}
+}
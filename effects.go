@@ -0,0 +1,73 @@
+package dvdlogo
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	wallBurstFrames   = 15
+	wallBurstRadius   = 20
+	cornerBurstFrames = 45
+	cornerBurstRadius = 90
+	cornerBurstRings  = 3
+)
+
+// burst is a short-lived ring effect drawn where a logo hit a wall or corner
+type burst struct {
+	x      float64
+	y      float64
+	frames int // Frames left before the burst is gone
+	corner bool
+}
+
+// duration returns how many frames the burst lasts in total
+func (b burst) duration() int {
+	if b.corner {
+		return cornerBurstFrames
+	}
+	return wallBurstFrames
+}
+
+// addBurst starts a burst at x, y, a big one for corner hits and a small one for walls
+func (g *Game) addBurst(x, y float64, corner bool) {
+	b := burst{x: x, y: y, corner: corner}
+	b.frames = b.duration()
+	g.bursts = append(g.bursts, b)
+}
+
+// updateBursts counts down the bursts and drops the finished ones
+func (g *Game) updateBursts() {
+	alive := g.bursts[:0]
+	for _, b := range g.bursts {
+		b.frames--
+		if b.frames > 0 {
+			alive = append(alive, b)
+		}
+	}
+	g.bursts = alive
+}
+
+// drawBursts draws every burst as rings that grow and fade out. Wall bounces
+// get a single small white ring, corner hits several large yellow ones.
+func (g *Game) drawBursts(screen *ebiten.Image) {
+	for _, b := range g.bursts {
+		progress := 1 - float32(b.frames)/float32(b.duration())
+		if !b.corner {
+			alpha := uint8(255 * (1 - progress))
+			vector.StrokeCircle(screen, float32(b.x), float32(b.y), wallBurstRadius*progress, 2, color.RGBA{alpha, alpha, alpha, alpha}, true)
+			continue
+		}
+		for ring := range cornerBurstRings {
+			// Stagger the rings so they ripple outwards one after another
+			p := progress - float32(ring)*0.2
+			if p <= 0 {
+				continue
+			}
+			alpha := uint8(255 * (1 - p))
+			vector.StrokeCircle(screen, float32(b.x), float32(b.y), cornerBurstRadius*p, 4, color.RGBA{alpha, alpha, 0, alpha}, true)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"slices"
+)
+
+// focusLosses lists what the game can do while its window is in the
+// background: keep running, pause until it's back, or open the pause menu
+var focusLosses = []string{"run", "pause", "menu"}
+
+// validFocusLoss rejects a mode that isn't one of focusLosses
+func validFocusLoss(mode string) error {
+	if !slices.Contains(focusLosses, mode) {
+		return fmt.Errorf("focusLoss %q must be one of %v", mode, focusLosses)
+	}
+	return nil
+}
+
+// followFocus pauses the game when the window loses focus, if the config
+// asks it to. A pause without the menu ends by itself once the window is
+// focused again, the menu waits for the continue key like any other pause.
+func (g *Game) followFocus() {
+	if g.config.FocusLoss == "run" {
+		return
+	}
+	focused := g.input.isFocused()
+	switch {
+	case focused && g.focusPaused:
+		g.focusPaused = false
+		g.setPaused(false)
+	case !focused && !g.paused:
+		g.setPaused(true)
+		g.focusPaused = g.config.FocusLoss == "pause"
+	}
+}
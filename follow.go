@@ -0,0 +1,46 @@
+package dvdlogo
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const followEdgeWidth = 3 // Width of the line along the world edges
+
+// cameraOffset returns the world position at the top-left of the screen.
+// Following puts the center of the first logo in the middle, otherwise the
+// world sits inside the margins.
+func (g *Game) cameraOffset() (float64, float64) {
+	if !g.follow {
+		return -float64(g.config.MarginLeft), -float64(g.config.MarginTop)
+	}
+	l := &g.logos[0]
+	x, y := l.interpolated(g.interpolation())
+	return x + l.width/2 - g.viewWidth/2, y + l.height/2 - g.viewHeight/2
+}
+
+// drawMovedWorld draws the world moved by the camera offset camX, camY, over
+// a line along the world edges, which also frames the area inside the
+// margins. The background pattern is drawn moved the same way, so the
+// movement shows when following. The physics still run in world
+// coordinates, only the drawing moves.
+func (g *Game) drawMovedWorld(screen *ebiten.Image, camX, camY float64) {
+	edge := lerpColor(g.backgroundColor, color.RGBA{255, 255, 255, 255}, followGridShade)
+	vector.StrokeRect(screen, float32(-camX), float32(-camY), float32(g.width), float32(g.height), followEdgeWidth, edge, false)
+
+	size := screen.Bounds().Size()
+	if g.world == nil || g.world.Bounds().Size() != size {
+		if g.world != nil {
+			g.world.Deallocate()
+		}
+		g.world = ebiten.NewImage(size.X, size.Y)
+	}
+	g.world.Clear()
+	g.drawWorld(g.world)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(-camX, -camY)
+	screen.DrawImage(g.world, op)
+}
@@ -0,0 +1,38 @@
+package dvdlogo
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	gaugeWidth  = 120 // Length of the speedometer bar when full
+	gaugeHeight = 6
+)
+
+// speedFraction returns the speed of the first logo as a share of the
+// fastest it can go with the current max velocity, from 0 to 1
+func (g *Game) speedFraction() float64 {
+	top := math.Hypot(g.maxVelocityX, g.maxVelocityY)
+	if top == 0 {
+		return 0
+	}
+	l := &g.logos[0]
+	return math.Min(1, math.Hypot(l.velocityX, l.velocityY)/top)
+}
+
+// drawSpeedGauge draws a bar at x, y filled up to the speed of the first
+// logo, in its color. A logo resting in gravity mode has no velocity left,
+// so the bar is empty.
+func (g *Game) drawSpeedGauge(screen *ebiten.Image, x, y float64) {
+	w, h := float32(gaugeWidth), float32(gaugeHeight)
+	fill := w * float32(g.speedFraction())
+	vector.DrawFilledRect(screen, float32(x), float32(y), w, h, color.RGBA{0, 0, 0, 160}, false)
+	if fill > 0 {
+		vector.DrawFilledRect(screen, float32(x), float32(y), fill, h, g.logoColor(&g.logos[0]), false)
+	}
+	vector.StrokeRect(screen, float32(x), float32(y), w, h, 1, color.White, false)
+}
@@ -0,0 +1,30 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"time"
+)
+
+// RunHeadless advances the physics ticks times without opening a window or
+// reading input, steering and sketching a -trace word like a windowed run
+// does, then prints the corner hits and how fast the ticks ran. A time trial
+// stops early, once its target is reached. Snapshot may be called from other
+// goroutines meanwhile, like while the game runs in a window.
+func (g *Game) RunHeadless(ticks int) {
+	start := time.Now()
+	for range ticks {
+		g.tick()
+		g.publish()
+		if g.finished {
+			ticks = g.ticks
+			break
+		}
+	}
+	elapsed := time.Since(start)
+	g.flushHeatmap()
+
+	fmt.Printf("Ticks: %d\n", ticks)
+	fmt.Printf("Corner hits: %d (%s)\n", g.totalCornerHits(), g.formatCornerHits())
+	fmt.Printf("Perfect corner hits: %d\n", g.perfectHits)
+	fmt.Printf("Average TPS: %.0f\n", float64(ticks)/elapsed.Seconds())
+}
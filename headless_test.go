@@ -0,0 +1,37 @@
+package dvdlogo
+
+import "testing"
+
+// runSeeded runs a headless session of ticks with the given seed, which
+// also picks the random colors
+func runSeeded(seed int64, ticks int) *Game {
+	opts := DefaultOptions()
+	opts.Seed = seed
+	opts.Count = 2
+	opts.RandomColors = true
+	g := NewGame(opts)
+	g.RunHeadless(ticks)
+	return g
+}
+
+func TestRunHeadlessSeedIsReproducible(t *testing.T) {
+	const ticks = 300000
+	a, b := runSeeded(3, ticks), runSeeded(3, ticks)
+	if a.totalCornerHits() == 0 {
+		t.Fatal("no corner hits, the run is too short to compare")
+	}
+	if a.cornerHits != b.cornerHits || a.perfectHits != b.perfectHits {
+		t.Errorf("corner hits %v and %v perfect differ from %v and %v", a.cornerHits, a.perfectHits, b.cornerHits, b.perfectHits)
+	}
+	for i := range a.logos {
+		if a.logos[i].x != b.logos[i].x || a.logos[i].y != b.logos[i].y || a.logos[i].colorIndex != b.logos[i].colorIndex {
+			t.Errorf("logo %d ended at %v, %v with color %d and at %v, %v with color %d", i,
+				a.logos[i].x, a.logos[i].y, a.logos[i].colorIndex, b.logos[i].x, b.logos[i].y, b.logos[i].colorIndex)
+		}
+	}
+
+	other := runSeeded(4, ticks)
+	if other.logos[0].x == a.logos[0].x && other.logos[0].y == a.logos[0].y {
+		t.Error("a different seed ended at the same spot")
+	}
+}
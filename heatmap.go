@@ -0,0 +1,70 @@
+package dvdlogo
+
+import (
+	"image"
+	"image/color"
+	"log"
+)
+
+// heatmap counts how many ticks the logos spent over each cell of a grid
+// laid over the screen
+type heatmap struct {
+	cols, rows int
+	counts     []int
+	path       string // Where flushHeatmap writes the PNG
+	written    bool
+}
+
+func newHeatmap(cols, rows int, path string) *heatmap {
+	return &heatmap{cols: cols, rows: rows, counts: make([]int, cols*rows), path: path}
+}
+
+// add counts a tick at x, y on a width x height screen. The cells stretch
+// along with the screen, so a resize doesn't shift the grid.
+func (h *heatmap) add(x, y, width, height float64) {
+	col := min(max(int(x/width*float64(h.cols)), 0), h.cols-1)
+	row := min(max(int(y/height*float64(h.rows)), 0), h.rows-1)
+	h.counts[row*h.cols+col]++
+}
+
+// image renders the counts as grayscale, from black for cells never visited
+// to white for the most visited one
+func (h *heatmap) image() *image.Gray {
+	peak := 0
+	for _, n := range h.counts {
+		peak = max(peak, n)
+	}
+	img := image.NewGray(image.Rect(0, 0, h.cols, h.rows))
+	if peak == 0 {
+		return img
+	}
+	for i, n := range h.counts {
+		img.SetGray(i%h.cols, i/h.cols, color.Gray{uint8(n * 255 / peak)})
+	}
+	return img
+}
+
+// trackHeatmap counts the cell under the center of every logo
+func (g *Game) trackHeatmap() {
+	if g.heatmap == nil {
+		return
+	}
+	for i := range g.logos {
+		l := &g.logos[i]
+		g.heatmap.add(l.x+l.width/2, l.y+l.height/2, g.width, g.height)
+	}
+}
+
+// flushHeatmap writes the heatmap PNG. Like the session stats it's only
+// written once, when the game quits.
+func (g *Game) flushHeatmap() {
+	if g.heatmap == nil || g.heatmap.written {
+		return
+	}
+	g.heatmap.written = true
+	if err := writePNG(g.heatmap.path, g.heatmap.image()); err != nil {
+		log.Printf("Could not save heatmap: %v", err)
+		return
+	}
+	log.Printf("Saved heatmap to %s", g.heatmap.path)
+}
@@ -0,0 +1,20 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ServeHTTP serves the current Snapshot as JSON, the same as a
+// -dump-interval line, for dashboards watching a running game
+func (g *Game) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(g.Snapshot()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
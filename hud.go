@@ -0,0 +1,82 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+)
+
+const (
+	hudMargin     = 10 // Distance of the HUD from the screen edges
+	hudLineHeight = 16
+)
+
+// drawHUD shows the speed of the first logo, the corner hits, in total, per
+// corner and perfect ones, the wall bounces, distance, elapsed time and any
+// running combo in the bottom-left corner, under a speedometer bar
+func (g *Game) drawHUD(screen *ebiten.Image) {
+	l := &g.logos[0]
+	lines := []string{
+		fmt.Sprintf("Speed: %.1f px/tick", math.Hypot(l.velocityX, l.velocityY)),
+		fmt.Sprintf("Hits: %d", g.totalCornerHits()),
+		g.formatCornerHits(),
+		fmt.Sprintf("Perfect: %d", g.perfectHits),
+		fmt.Sprintf("Bounces: %d", g.wallBounces),
+		fmt.Sprintf("Distance: %s", formatDistance(g.distanceTraveled)),
+		fmt.Sprintf("Time: %s", formatElapsed(g.elapsed())),
+	}
+	if g.combo > 1 {
+		lines = append(lines, fmt.Sprintf("Combo: x%d", g.combo))
+	}
+	y := g.viewHeight - hudMargin - float64(len(lines)-1)*hudLineHeight
+	// y is the baseline of the first line, the gauge goes a line above it
+	g.drawSpeedGauge(screen, hudMargin, y-hudLineHeight-gaugeHeight)
+	for _, line := range lines {
+		g.drawShadowedText(screen, line, hudMargin, y)
+		y += hudLineHeight
+	}
+}
+
+// formatDistance formats a distance in pixels, switching to thousands of
+// pixels once it gets long
+func formatDistance(px float64) string {
+	if px >= 10000 {
+		return fmt.Sprintf("%.1fk px", px/1000)
+	}
+	return fmt.Sprintf("%.0f px", px)
+}
+
+// drawRecordBanner shows NEW RECORD across the middle of the screen
+func (g *Game) drawRecordBanner(screen *ebiten.Image) {
+	str := fmt.Sprintf("NEW RECORD: %d", g.bestSession)
+	y := g.viewHeight / 2
+	ebitenutil.DrawRect(screen, 0, y-2*hudLineHeight, g.viewWidth, 3*hudLineHeight, color.RGBA{0, 0, 0, 160})
+	g.drawShadowedText(screen, str, g.viewWidth/2-g.textWidth(str)/2, y)
+}
+
+// drawShadowedText draws white text with a black drop shadow, so it stays
+// readable on both the blue background and the green corner flash
+func (g *Game) drawShadowedText(screen *ebiten.Image, str string, x, y float64) {
+	g.drawText(screen, str, x+1, y+1, color.Black)
+	g.drawText(screen, str, x, y, color.White)
+}
+
+// drawText draws str with its baseline starting at x, y. Ebiten already
+// scales the whole screen up on high-DPI displays, text included.
+func (g *Game) drawText(screen *ebiten.Image, str string, x, y float64, clr color.Color) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(clr)
+	text.DrawWithOptions(screen, str, basicfont.Face7x13, op)
+}
+
+// textWidth returns how wide drawText draws str
+func (g *Game) textWidth(str string) float64 {
+	return float64(font.MeasureString(basicfont.Face7x13, str).Ceil())
+}
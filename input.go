@@ -0,0 +1,95 @@
+package dvdlogo
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// input is the keyboard, mouse and screen size the game reacts to, read
+// through an interface so a replay can stand in for the real devices
+type input interface {
+	// next moves on to the input of the next tick, false once there is none left
+	next() bool
+	isKeyPressed(key ebiten.Key) bool
+	isMouseButtonPressed(button ebiten.MouseButton) bool
+	cursorPosition() (int, int)
+	// wheel returns how far the mouse wheel scrolled this tick
+	wheel() (float64, float64)
+	isFocused() bool
+	// isWindowBeingClosed reports whether the window's close button was pressed
+	isWindowBeingClosed() bool
+	// screenSize returns the size to lay the screen out at, given the window size
+	screenSize(outsideWidth, outsideHeight int) (int, int)
+}
+
+// liveInput reads the real keyboard and mouse
+type liveInput struct{}
+
+func (liveInput) next() bool { return true }
+
+func (liveInput) isKeyPressed(key ebiten.Key) bool { return ebiten.IsKeyPressed(key) }
+
+func (liveInput) isMouseButtonPressed(button ebiten.MouseButton) bool {
+	return ebiten.IsMouseButtonPressed(button)
+}
+
+func (liveInput) cursorPosition() (int, int) { return ebiten.CursorPosition() }
+
+func (liveInput) wheel() (float64, float64) { return ebiten.Wheel() }
+
+func (liveInput) isFocused() bool { return ebiten.IsFocused() }
+
+func (liveInput) isWindowBeingClosed() bool { return ebiten.IsWindowBeingClosed() }
+
+func (liveInput) screenSize(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
+
+// inputFrame is the input of one tick, as stored in a replay file
+type inputFrame struct {
+	Keys    []ebiten.Key         `json:"keys,omitempty"`    // Held keys, by name
+	Buttons []ebiten.MouseButton `json:"buttons,omitempty"` // Held mouse buttons
+	Blurred bool                 `json:"blurred,omitempty"` // The window wasn't focused
+	Closed  bool                 `json:"closed,omitempty"`  // The window was being closed
+	X       int                  `json:"x,omitempty"`       // Cursor position
+	Y       int                  `json:"y,omitempty"`
+	WheelX  float64              `json:"wheelX,omitempty"` // Mouse wheel scroll
+	WheelY  float64              `json:"wheelY,omitempty"`
+	Width   int                  `json:"width"` // Screen size
+	Height  int                  `json:"height"`
+}
+
+// capture reads the current state of in into a frame
+func capture(in input, width, height int) inputFrame {
+	f := inputFrame{Width: width, Height: height}
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if in.isKeyPressed(key) {
+			f.Keys = append(f.Keys, key)
+		}
+	}
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if in.isMouseButtonPressed(b) {
+			f.Buttons = append(f.Buttons, b)
+		}
+	}
+	f.X, f.Y = in.cursorPosition()
+	f.WheelX, f.WheelY = in.wheel()
+	f.Blurred = !in.isFocused()
+	f.Closed = in.isWindowBeingClosed()
+	return f
+}
+
+func (f inputFrame) isKeyPressed(key ebiten.Key) bool {
+	for _, k := range f.Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (f inputFrame) isMouseButtonPressed(button ebiten.MouseButton) bool {
+	for _, b := range f.Buttons {
+		if b == button {
+			return true
+		}
+	}
+	return false
+}
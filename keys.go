@@ -0,0 +1,118 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Actions whose keys can be remapped in the config file
+const (
+	actionPause      = "pause"
+	actionContinue   = "continue"
+	actionQuit       = "quit"
+	actionRestart    = "restart"
+	actionFullscreen = "fullscreen"
+	actionDump       = "dump"
+	actionHide       = "hide"
+)
+
+// defaultKeyBindings maps each remappable action to its built-in key name
+var defaultKeyBindings = map[string]string{
+	actionPause:      "Escape",
+	actionContinue:   "C",
+	actionQuit:       "Q",
+	actionRestart:    "R",
+	actionFullscreen: "F",
+	actionDump:       "P",
+	actionHide:       "Backquote",
+}
+
+// fixedKeys names what the keys of the controls that can't be remapped do.
+// Space isn't one of them: binding an action to it turns off teleporting.
+var fixedKeys = map[ebiten.Key]string{
+	ebiten.KeyArrowLeft:      "nudge",
+	ebiten.KeyArrowRight:     "nudge",
+	ebiten.KeyArrowUp:        "nudge",
+	ebiten.KeyArrowDown:      "nudge",
+	ebiten.KeyT:              "slow motion",
+	ebiten.KeyH:              "HUD",
+	ebiten.KeyK:              "clock",
+	ebiten.KeyZ:              "screen size",
+	ebiten.KeyN:              "minimap",
+	ebiten.KeyV:              "follow",
+	ebiten.KeyF12:            "screenshot",
+	ebiten.KeyG:              "GIF recording",
+	ebiten.KeyE:              "sketch",
+	ebiten.KeyX:              "wipe sketch",
+	ebiten.KeyBracketLeft:    "previous color",
+	ebiten.KeyBracketRight:   "next color",
+	ebiten.KeyA:              "color cycling",
+	ebiten.KeyL:              "next logo",
+	ebiten.KeyD:              "debug overlay",
+	ebiten.KeyM:              "mute",
+	ebiten.KeyB:              "bounce sound",
+	ebiten.KeyEqual:          "volume up",
+	ebiten.KeyNumpadAdd:      "volume up",
+	ebiten.KeyMinus:          "volume down",
+	ebiten.KeyNumpadSubtract: "volume down",
+	ebiten.KeyS:              "settings",
+	ebiten.KeyPeriod:         "step",
+	ebiten.KeyPageUp:         "speed up",
+	ebiten.KeyPageDown:       "slow down",
+}
+
+// parseKeyBindings turns the action to key name bindings from the config into
+// ebiten keys, rejecting unknown actions, unknown key names, keys bound twice
+// and the fixed keys of the other controls
+func parseKeyBindings(bindings map[string]string) (map[string]ebiten.Key, error) {
+	// Go through the actions in order, so the same config always gives the same error
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	keys := make(map[string]ebiten.Key, len(bindings))
+	boundTo := make(map[ebiten.Key]string, len(bindings))
+	for _, action := range actions {
+		if _, ok := defaultKeyBindings[action]; !ok {
+			return nil, fmt.Errorf("unknown action %q, expected one of %s", action, strings.Join(keyActions(), ", "))
+		}
+		var key ebiten.Key
+		if err := key.UnmarshalText([]byte(bindings[action])); err != nil {
+			return nil, fmt.Errorf("%s: unknown key name %q, use names like \"A\", \"Escape\", \"Space\" or \"F1\"", action, bindings[action])
+		}
+		if other, ok := boundTo[key]; ok {
+			return nil, fmt.Errorf("%s and %s are both bound to %s", other, action, key)
+		}
+		if control, ok := fixedKeys[key]; ok {
+			return nil, fmt.Errorf("%s can't be bound to %s, which is the %s key", action, key, control)
+		}
+		keys[action] = key
+		boundTo[key] = action
+	}
+	return keys, nil
+}
+
+// isActionKey reports whether key is bound to one of the remappable actions
+func (g *Game) isActionKey(key ebiten.Key) bool {
+	for _, k := range g.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// keyActions lists the remappable actions in alphabetical order
+func keyActions() []string {
+	actions := make([]string, 0, len(defaultKeyBindings))
+	for action := range defaultKeyBindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
@@ -0,0 +1,44 @@
+package dvdlogo
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestParseKeyBindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string]string
+		wantErr  bool
+	}{
+		{"defaults", defaultKeyBindings, false},
+		{"remapped", map[string]string{actionPause: "Pause", actionQuit: "F10"}, false},
+		{"space", map[string]string{actionPause: "Space"}, false},
+		{"unknown action", map[string]string{"jump": "J"}, true},
+		{"unknown key", map[string]string{actionPause: "Esc"}, true},
+		{"bound twice", map[string]string{actionPause: "P", actionDump: "P"}, true},
+		{"fixed key", map[string]string{actionQuit: "M"}, true},
+		{"fixed arrow key", map[string]string{actionRestart: "ArrowUp"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseKeyBindings(tt.bindings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for action, name := range tt.bindings {
+				var want ebiten.Key
+				if err := want.UnmarshalText([]byte(name)); err != nil {
+					t.Fatal(err)
+				}
+				if keys[action] != want {
+					t.Errorf("%s bound to %s, want %s", action, keys[action], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package dvdlogo
+
+import "math"
+
+// Logo holds the position, velocity and tint of one bouncing logo
+type Logo struct {
+	x          float64
+	y          float64
+	velocityX  float64
+	velocityY  float64
+	width      float64
+	height     float64
+	colorIndex int
+	trail      trail
+
+	// Displacement of the last move, used to tell where a fast logo touched a wall
+	stepX float64
+	stepY float64
+
+	// Position before the last tick, drawn frames interpolate from here
+	prevX float64
+	prevY float64
+
+	// Ticks left of springing back from the last bounce off a left/right and
+	// a top/bottom wall
+	squashX int
+	squashY int
+}
+
+// squashScale returns how much to scale the drawn logo along x and y for
+// the bounces it's springing back from, where squash is the share it's
+// squashed by right at the bounce
+func (l *Logo) squashScale(squash float64) (float64, float64) {
+	x, y := squashAmount(l.squashX, squash), squashAmount(l.squashY, squash)
+	// Squashing along one axis stretches the logo along the other
+	return 1 - x + y, 1 - y + x
+}
+
+// squashAmount returns the share a logo is squashed by ticksLeft ticks
+// before it has sprung back. It overshoots into a stretch halfway, like a
+// damped spring, and settles as it nears 0.
+func squashAmount(ticksLeft int, squash float64) float64 {
+	t := 1 - float64(ticksLeft)/squashTicks
+	return squash * (1 - t) * math.Cos(2*math.Pi*t)
+}
+
+// move advances the logo by dt seconds of its velocity, adding where it was to the trail
+func (l *Logo) move(dt float64) {
+	l.trail.push(point{l.x, l.y})
+	l.stepX = l.velocityX * dt * referenceTPS
+	l.stepY = l.velocityY * dt * referenceTPS
+	l.x += l.stepX
+	l.y += l.stepY
+}
+
+// contactFraction returns how far back along a step of size step the logo
+// touched the wall it overshot by overshoot, from 0 (at the end) to 1 (at the start)
+func contactFraction(overshoot, step float64) float64 {
+	if step == 0 {
+		return 0
+	}
+	return math.Min(1, overshoot/math.Abs(step))
+}
+
+// bounceOffWalls reflects the logo off the screen borders and reports which
+// velocity components were reversed. A bounce that lands within tolerance
+// of a perpendicular wall the logo is heading towards bounces off that wall too,
+// so reaching a corner always reverses both components on the same frame.
+// For a corner, miss is how far the logo was from the second wall when it
+// touched the first one, 0 for an exact corner.
+func (l *Logo) bounceOffWalls(width, height, tolerance float64) (bouncedX, bouncedY bool, miss float64) {
+	maxX := width - l.width
+	maxY := height - l.height
+
+	var overshootX, overshootY float64
+	l.x, l.velocityX, overshootX, bouncedX = reflect(l.x, l.velocityX, maxX)
+	l.y, l.velocityY, overshootY, bouncedY = reflect(l.y, l.velocityY, maxY)
+	if bouncedX && bouncedY {
+		// Both walls were reached within the step, the one touched later was
+		// still short of its wall by the time between the two contacts
+		fx, fy := contactFraction(overshootX, l.stepX), contactFraction(overshootY, l.stepY)
+		if fx > fy {
+			miss = (fx - fy) * math.Abs(l.stepY)
+		} else {
+			miss = (fy - fx) * math.Abs(l.stepX)
+		}
+	}
+
+	// Snap into the corner when a bounce lands just short of it. A fast logo
+	// can overshoot a wall by a lot in one step, so judge by where it was along
+	// the other axis at the moment it touched the wall.
+	if bouncedX && !bouncedY {
+		y := l.y - l.stepY*contactFraction(overshootX, l.stepX)
+		if y < tolerance && l.velocityY < 0 {
+			l.y = 0
+			l.velocityY = -l.velocityY
+			bouncedY, miss = true, y
+		} else if y > maxY-tolerance && l.velocityY > 0 {
+			l.y = math.Max(0, maxY)
+			l.velocityY = -l.velocityY
+			bouncedY, miss = true, maxY-y
+		}
+	}
+	if bouncedY && !bouncedX {
+		x := l.x - l.stepX*contactFraction(overshootY, l.stepY)
+		if x < tolerance && l.velocityX < 0 {
+			l.x = 0
+			l.velocityX = -l.velocityX
+			bouncedX, miss = true, x
+		} else if x > maxX-tolerance && l.velocityX > 0 {
+			l.x = math.Max(0, maxX)
+			l.velocityX = -l.velocityX
+			bouncedX, miss = true, maxX-x
+		}
+	}
+
+	return bouncedX, bouncedY, math.Max(0, miss)
+}
+
+// reflect bounces pos off the walls at 0 and limit. A position past a wall is
+// mirrored back inside by the overshoot, rather than just set onto the wall,
+// and the velocity is pointed away from that wall, so the next step can't
+// trigger the same wall again and leave the logo vibrating against it.
+func reflect(pos, velocity, limit float64) (newPos, newVelocity, overshoot float64, bounced bool) {
+	switch {
+	case pos < 0:
+		overshoot = -pos
+		return math.Min(overshoot, math.Max(0, limit)), math.Abs(velocity), overshoot, true
+	case pos > limit:
+		overshoot = pos - limit
+		return math.Max(0, limit-overshoot), -math.Abs(velocity), overshoot, true
+	}
+	return pos, velocity, 0, false
+}
+
+// onFloor reports whether the logo rests on the bottom of a screen of the given height
+func (l *Logo) onFloor(height float64) bool {
+	return l.velocityY == 0 && l.y >= height-l.height
+}
+
+// applyGravity accelerates the logo downwards for dt seconds. A logo resting on
+// the floor isn't pulled into it, but slides to a halt instead.
+func (l *Logo) applyGravity(gravity, height, dt float64) {
+	if l.onFloor(height) {
+		l.velocityX *= math.Pow(floorFriction, dt*referenceTPS)
+		if math.Abs(l.velocityX) < restVelocity {
+			l.velocityX = 0
+		}
+		return
+	}
+	l.velocityY += gravity * dt * referenceTPS
+}
+
+// dampFloorBounce keeps restitution of the velocity after a bounce off the
+// floor, and puts the logo to rest once the bounce is too small to see
+func (l *Logo) dampFloorBounce(height, restitution float64) {
+	l.velocityX *= restitution
+	l.velocityY *= restitution
+	if math.Abs(l.velocityY) < restVelocity {
+		l.velocityY = 0
+		l.y = math.Max(0, height-l.height)
+	}
+}
+
+// steerToCorner turns the velocity by at most maxTurn radians towards the
+// corner the logo is heading for, keeping its speed. The turn is small enough
+// to look like a natural path while making corner hits a lot more frequent.
+func (l *Logo) steerToCorner(width, height, maxTurn float64) {
+	// Distance from the leading edges of the logo to the walls it's heading for
+	dx := l.x
+	if l.velocityX > 0 {
+		dx = width - l.x - l.width
+	}
+	dy := l.y
+	if l.velocityY > 0 {
+		dy = height - l.y - l.height
+	}
+	target := math.Atan2(math.Copysign(dy, l.velocityY), math.Copysign(dx, l.velocityX))
+	current := math.Atan2(l.velocityY, l.velocityX)
+
+	// Turn the short way round, no further than maxTurn
+	turn := math.Remainder(target-current, 2*math.Pi)
+	turn = math.Max(-maxTurn, math.Min(turn, maxTurn))
+	speed := math.Hypot(l.velocityX, l.velocityY)
+	l.velocityX = speed * math.Cos(current+turn)
+	l.velocityY = speed * math.Sin(current+turn)
+}
+
+// wobble turns the velocity by angle radians after a bounce, keeping its
+// speed. A turn that would point a bounced component back into its wall is
+// skipped, so a logo skimming along a wall can't be sent through it.
+func (l *Logo) wobble(angle float64, bouncedX, bouncedY bool) {
+	sin, cos := math.Sincos(angle)
+	vx := l.velocityX*cos - l.velocityY*sin
+	vy := l.velocityX*sin + l.velocityY*cos
+	if bouncedX && math.Signbit(vx) != math.Signbit(l.velocityX) ||
+		bouncedY && math.Signbit(vy) != math.Signbit(l.velocityY) {
+		return
+	}
+	l.velocityX, l.velocityY = vx, vy
+}
+
+// jitterBounce moves a logo that just bounced off a wall up to dx or dy
+// further away from it, so the bounce lands a little off where pure
+// reflection puts it and the path slowly drifts instead of repeating
+func (l *Logo) jitterBounce(dx, dy float64, bouncedX, bouncedY bool) {
+	// The velocity already points away from the wall that was hit
+	if bouncedX {
+		l.x += math.Copysign(dx, l.velocityX)
+	}
+	if bouncedY {
+		l.y += math.Copysign(dy, l.velocityY)
+	}
+}
+
+// contactPoint returns where the logo touched the walls it just bounced off,
+// the corner itself when it bounced off two
+func (l *Logo) contactPoint(bouncedX, bouncedY bool, width, height float64) (float64, float64) {
+	x := l.x + l.width/2
+	y := l.y + l.height/2
+	// The velocity already points away from the wall that was hit
+	if bouncedX {
+		x = 0
+		if l.velocityX < 0 {
+			x = width
+		}
+	}
+	if bouncedY {
+		y = 0
+		if l.velocityY < 0 {
+			y = height
+		}
+	}
+	return x, y
+}
+
+// gravityWell returns the acceleration per tick pulling the logo center
+// towards x, y, or pushing it away for a negative strength. The pull gets
+// weaker with the distance, so a logo passing by is bent into an orbit.
+func (l *Logo) gravityWell(x, y, strength float64) (float64, float64) {
+	dx := x - (l.x + l.width/2)
+	dy := y - (l.y + l.height/2)
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		// Right on the cursor there's no direction to pull in
+		return 0, 0
+	}
+	accel := strength / math.Max(dist, wellMinDistance)
+	return dx / dist * accel, dy / dist * accel
+}
+
+// accelerate adds ax, ay per tick to the velocity for dt seconds, keeping
+// each velocity component within the max velocity of its axis
+func (l *Logo) accelerate(ax, ay, maxVelocityX, maxVelocityY, dt float64) {
+	l.velocityX += ax * dt * referenceTPS
+	l.velocityY += ay * dt * referenceTPS
+
+	// Clamp velocity to the adjustable max velocity
+	if math.Abs(l.velocityX) > maxVelocityX {
+		l.velocityX = math.Copysign(maxVelocityX, l.velocityX)
+	}
+	if math.Abs(l.velocityY) > maxVelocityY {
+		l.velocityY = math.Copysign(maxVelocityY, l.velocityY)
+	}
+}
+
+// clampTo pulls the logo back inside a width x height screen, pinning it to
+// the top-left edge if the screen is smaller than the logo
+func (l *Logo) clampTo(width, height float64) {
+	// The logo jumps to its clamped spot, so the old positions no longer connect to it
+	l.trail.clear()
+	l.constrain(width, height)
+	l.jumped()
+}
+
+// jumped tells the logo it was placed somewhere new instead of moving
+// there, so drawing doesn't slide it over from the old spot
+func (l *Logo) jumped() {
+	l.prevX, l.prevY = l.x, l.y
+}
+
+// interpolated returns the position to draw the logo at, a fraction t of the
+// way through the last tick. Both ends of the last tick are on the screen,
+// so the positions in between never overshoot a wall, even on a bounce,
+// where they cut a little inside the wall instead.
+func (l *Logo) interpolated(t float64) (x, y float64) {
+	return l.prevX + (l.x-l.prevX)*t, l.prevY + (l.y-l.prevY)*t
+}
+
+// constrain keeps the logo inside a width x height screen
+func (l *Logo) constrain(width, height float64) {
+	l.x = math.Max(0, math.Min(l.x, width-l.width))
+	l.y = math.Max(0, math.Min(l.y, height-l.height))
+}
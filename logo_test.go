@@ -0,0 +1,144 @@
+package dvdlogo
+
+import "testing"
+
+const (
+	testWidth  = 800
+	testHeight = 600
+	testStep   = 1.0 / referenceTPS
+)
+
+// testLogo returns a 120x60 logo at x, y moving by vx, vy per tick
+func testLogo(x, y, vx, vy float64) *Logo {
+	return &Logo{x: x, y: y, velocityX: vx, velocityY: vy, width: 120, height: 60}
+}
+
+func TestBounceOffWallsCorners(t *testing.T) {
+	tests := []struct {
+		name   string
+		logo   *Logo
+		corner bool
+	}{
+		{"top-left", testLogo(1, 1, -2, -2), true},
+		{"top-right", testLogo(testWidth-120-1, 1, 2, -2), true},
+		{"bottom-left", testLogo(1, testHeight-60-1, -2, 2), true},
+		{"bottom-right", testLogo(testWidth-120-1, testHeight-60-1, 2, 2), true},
+		{"within tolerance", testLogo(1, 4, -2, -2), true},
+		{"left wall", testLogo(1, 300, -2, 2), false},
+		{"top wall", testLogo(400, 1, 2, -2), false},
+		{"right wall", testLogo(testWidth-120-1, 300, 2, -2), false},
+		{"bottom wall", testLogo(400, testHeight-60-1, -2, 2), false},
+		// Near the top but heading away from it, so only the left wall is reached
+		{"left wall leaving the top", testLogo(1, 3, -2, 2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vx, vy := tt.logo.velocityX, tt.logo.velocityY
+			tt.logo.move(testStep)
+			bouncedX, bouncedY, _ := tt.logo.bounceOffWalls(testWidth, testHeight, cornerTolerance)
+			if got := bouncedX && bouncedY; got != tt.corner {
+				t.Fatalf("corner = %v (bounced x %v, y %v), want %v", got, bouncedX, bouncedY, tt.corner)
+			}
+			if tt.corner && (tt.logo.velocityX != -vx || tt.logo.velocityY != -vy) {
+				t.Errorf("velocity %v, %v not reversed from %v, %v", tt.logo.velocityX, tt.logo.velocityY, vx, vy)
+			}
+		})
+	}
+}
+
+func TestBounceOffWallsDoesNotVibrate(t *testing.T) {
+	for _, speed := range []float64{0.3, 2, 3.7, 12, 40, 130} {
+		l := testLogo(300, 200, -speed, 0.1)
+		bounces, lastBounce := 0, -1
+		for tick := range 5000 {
+			before := l.velocityX
+			l.move(testStep)
+			bouncedX, _, _ := l.bounceOffWalls(testWidth, testHeight, cornerTolerance)
+			if l.x < 0 || l.x > testWidth-l.width {
+				t.Fatalf("speed %v, tick %d: x %v is outside the screen", speed, tick, l.x)
+			}
+			if !bouncedX {
+				continue
+			}
+			// A logo wedged against a wall bounces off it again on the next tick
+			if lastBounce == tick-1 {
+				t.Fatalf("speed %v: bounced on both tick %d and %d", speed, lastBounce, tick)
+			}
+			bounces, lastBounce = bounces+1, tick
+			// Each bounce sends the logo off towards the opposite wall, never
+			// back into the one it just left
+			if l.velocityX != -before {
+				t.Fatalf("speed %v, tick %d: velocity %v after bouncing with %v", speed, tick, l.velocityX, before)
+			}
+			if headingRight := l.velocityX > 0; headingRight != (l.x < testWidth/2) {
+				t.Fatalf("speed %v, tick %d: at x %v heading the wrong way with velocity %v", speed, tick, l.x, l.velocityX)
+			}
+		}
+		// Crossing the screen takes (800-120)/speed ticks
+		if want := int(5000 * speed / (testWidth - 120)); bounces < want-1 || bounces > want+1 {
+			t.Errorf("speed %v: %d bounces in 5000 ticks, want about %d", speed, bounces, want)
+		}
+	}
+}
+
+func TestAccelerateClampsPerAxis(t *testing.T) {
+	tests := []struct {
+		name           string
+		vx, vy, ax, ay float64
+		wantX, wantY   float64
+	}{
+		{"within both caps", 2, 1, 1, 1, 3, 2},
+		{"only x capped", 7, 1, 2, 1, 8, 2},
+		{"only y capped", 1, 2.5, 1, 1, 2, 3},
+		{"both capped", 7, 2.5, 5, 5, 8, 3},
+		{"capped going left and up", -7, -2.5, -5, -5, -8, -3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := testLogo(0, 0, tt.vx, tt.vy)
+			l.accelerate(tt.ax, tt.ay, 8, 3, testStep)
+			if l.velocityX != tt.wantX || l.velocityY != tt.wantY {
+				t.Errorf("velocity %v, %v, want %v, %v", l.velocityX, l.velocityY, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestRotatedLogoBouncesInsideWalls(t *testing.T) {
+	for _, speed := range []float64{0.2, 2, 12} {
+		g := NewGame(DefaultOptions())
+		l := &g.logos[0]
+		l.velocityX, l.velocityY = speed, speed*0.7
+		lastX, lastY := -1, -1
+		for tick := range 20000 {
+			vx, vy := l.velocityX, l.velocityY
+			g.tick()
+			// The box around the turned logo, as of the rotation this tick bounced it at
+			o := g.rotatedOverhang()
+			if l.x-o.left < -1e-9 || l.x+l.width+o.right > g.width+1e-9 || l.y-o.top < -1e-9 || l.y+l.height+o.bottom > g.height+1e-9 {
+				t.Fatalf("speed %v, tick %d: logo at %v, %v turned by %v reaches past a wall", speed, tick, l.x, l.y, g.rotation)
+			}
+			if l.velocityX != vx {
+				if lastX == tick-1 {
+					t.Fatalf("speed %v: bounced off a left or right wall on both tick %d and %d", speed, lastX, tick)
+				}
+				lastX = tick
+			}
+			if l.velocityY != vy {
+				if lastY == tick-1 {
+					t.Fatalf("speed %v: bounced off a top or bottom wall on both tick %d and %d", speed, lastY, tick)
+				}
+				lastY = tick
+			}
+			if l.squashX == squashTicks && l.velocityX == vx || l.squashY == squashTicks && l.velocityY == vy {
+				t.Fatalf("speed %v, tick %d: counted a bounce without turning around", speed, tick)
+			}
+		}
+		if g.rotation == 0 {
+			t.Fatalf("speed %v: the logo never turned", speed)
+		}
+		if g.wallBounces == 0 {
+			t.Errorf("speed %v: never bounced", speed)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package dvdlogo
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// badgeTexts are the drawn logos shipped along with the DVD one, for L to
+// cycle through
+var badgeTexts = []string{"VHS", "CD"}
+
+// logoChoice is one of the logos L cycles through
+type logoChoice struct {
+	source image.Image // For the hitbox and the window icon
+	sheet  *ebiten.Image
+	rects  []image.Rectangle // Frames of the sheet, a single one for a still logo
+}
+
+// newLogoChoice prepares logo for drawing, split into the frames at rects
+func newLogoChoice(logo image.Image, rects []image.Rectangle) logoChoice {
+	return logoChoice{source: logo, sheet: ebiten.NewImageFromImage(logo), rects: rects}
+}
+
+// newLogoChoices returns the logos L cycles through, starting with the one in
+// opts, which must be valid. The built-in ones are still logos, it's only the
+// first that is split into the configured sprite sheet frames.
+func newLogoChoices(opts Options) []logoChoice {
+	logo := opts.logo()
+	rects, _ := logoFrameRects(logo.Bounds().Size(), opts.Config)
+	choices := []logoChoice{newLogoChoice(logo, rects)}
+
+	still := func(img image.Image) logoChoice {
+		return newLogoChoice(img, []image.Rectangle{{Max: img.Bounds().Size()}})
+	}
+	if opts.Logo != nil {
+		// Custom logos come first, then the DVD one they replaced
+		choices = append(choices, still(builtinLogo()))
+	}
+	for _, text := range badgeTexts {
+		choices = append(choices, still(badgeLogo(text)))
+	}
+	return choices
+}
+
+// useLogo switches every logo to choice i, scaled to the configured width.
+// Logos the new shape leaves sticking out past a wall are pulled back in.
+func (g *Game) useLogo(i int) {
+	c := g.logoChoices[i]
+	frame := c.rects[0]
+	g.logoIndex = i
+	g.logoFrames = sliceFrames(c.sheet, c.rects)
+	g.icon = cropImage(c.source, frame)
+	g.logoScale = g.config.LogoWidth / float64(frame.Dx())
+	g.hitbox = image.Rect(0, 0, frame.Dx(), frame.Dy())
+	if g.tight {
+		g.hitbox = opaqueBounds(c.source, c.rects)
+	}
+	g.logoWidth = float64(g.hitbox.Dx()) * g.logoScale
+	g.logoHeight = float64(g.hitbox.Dy()) * g.logoScale
+
+	for i := range g.logos {
+		l := &g.logos[i]
+		l.width, l.height = g.logoWidth, g.logoHeight
+		l.clampTo(g.width, g.height)
+	}
+	g.centerObstacle()
+}
+
+// nextLogo switches to the next logo that fits the screen height at the
+// configured width, updating the window icon to match
+func (g *Game) nextLogo() {
+	for step := 1; step < len(g.logoChoices); step++ {
+		i := (g.logoIndex + step) % len(g.logoChoices)
+		frame := g.logoChoices[i].rects[0]
+		if g.config.LogoWidth/float64(frame.Dx())*float64(frame.Dy()) >= g.height {
+			continue
+		}
+		g.useLogo(i)
+		ebiten.SetWindowIcon([]image.Image{g.icon})
+		return
+	}
+}
@@ -0,0 +1,13 @@
+package dvdlogo
+
+// innerSize returns the size of the area the logos bounce in on a width x
+// height screen, inside the margins
+func (c Config) innerSize(width, height int) (int, int) {
+	return width - c.MarginLeft - c.MarginRight, height - c.MarginTop - c.MarginBottom
+}
+
+// hasMargins reports whether the logos bounce inside an inset instead of
+// off the screen edges
+func (c Config) hasMargins() bool {
+	return c.MarginTop != 0 || c.MarginBottom != 0 || c.MarginLeft != 0 || c.MarginRight != 0
+}
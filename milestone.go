@@ -0,0 +1,81 @@
+package dvdlogo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	milestoneBannerFrames = 180  // How long the milestone banner stays up
+	chimeSeconds          = 0.4  // Length of the milestone chime, split between its two notes
+	chimeDecay            = 12   // How fast each note fades, per second
+	chimeLoudness         = 0.25 // Peak of the chime, quieter than the corner hit
+)
+
+// chimeNotes are the frequencies of the milestone chime, played one after the other
+var chimeNotes = []float64{880, 1320}
+
+// checkMilestone shows a banner and plays a chime whenever the session time,
+// not counting pauses, passes another whole milestoneInterval. Counting the
+// intervals passed rather than looking for the time to line up makes each
+// one fire exactly once, however the ticks fall.
+func (g *Game) checkMilestone() {
+	interval := g.config.MilestoneInterval
+	if interval <= 0 {
+		return
+	}
+	n := int(g.elapsed().Seconds() / interval)
+	if n <= g.milestones {
+		return
+	}
+	g.milestones = n
+	g.milestoneShown = milestoneBannerFrames
+	if g.config.MilestoneSound {
+		playSound(g.chimePlayer)
+	}
+}
+
+// drawMilestone shows the session time of the last milestone at the top of the screen
+func (g *Game) drawMilestone(screen *ebiten.Image) {
+	d := time.Duration(float64(g.milestones) * g.config.MilestoneInterval * float64(time.Second))
+	str := formatMilestone(d.Round(time.Second))
+	y := float64(hudMargin + hudLineHeight)
+	g.drawShadowedText(screen, str, g.viewWidth/2-g.textWidth(str)/2, y)
+}
+
+// formatMilestone formats d in the largest unit it's a whole number of
+func formatMilestone(d time.Duration) string {
+	unit, name := time.Second, "second"
+	switch {
+	case d%time.Hour == 0:
+		unit, name = time.Hour, "hour"
+	case d%time.Minute == 0:
+		unit, name = time.Minute, "minute"
+	}
+	n := int(d / unit)
+	if n != 1 {
+		name += "s"
+	}
+	return fmt.Sprintf("%d %s played", n, name)
+}
+
+// chimeSound draws up the milestone chime as 16-bit stereo samples, each
+// note fading out before the next one starts
+func chimeSound() []byte {
+	noteSamples := int(chimeSeconds * sampleRate / float64(len(chimeNotes)))
+	buf := make([]byte, 0, 4*noteSamples*len(chimeNotes))
+	for _, freq := range chimeNotes {
+		for i := range noteSamples {
+			t := float64(i) / sampleRate
+			v := int16(math.Sin(2*math.Pi*freq*t) * math.Exp(-chimeDecay*t) * chimeLoudness * math.MaxInt16)
+			// The same sample on the left and right channel
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(v))
+			buf = binary.LittleEndian.AppendUint16(buf, uint16(v))
+		}
+	}
+	return buf
+}
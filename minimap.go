@@ -0,0 +1,51 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const minimapDotRadius = 2
+
+// minimapCorners lists the corners the minimap can be placed in
+var minimapCorners = []string{"top-left", "top-right", "bottom-left", "bottom-right"}
+
+// validMinimapCorner rejects a corner that isn't one of minimapCorners
+func validMinimapCorner(corner string) error {
+	if !slices.Contains(minimapCorners, corner) {
+		return fmt.Errorf("minimapCorner %q must be one of %v", corner, minimapCorners)
+	}
+	return nil
+}
+
+// drawMinimap shows the area the logos bounce in scaled down to minimapWidth
+// in the configured corner of the screen, with a dot in the logo color for
+// every logo
+func (g *Game) drawMinimap(screen *ebiten.Image) {
+	scale := float64(g.config.MinimapWidth) / g.width
+	w, h := g.width*scale, g.height*scale
+	x, y := float64(hudMargin), float64(hudMargin)
+	switch g.config.MinimapCorner {
+	case "top-right":
+		x = g.viewWidth - hudMargin - w
+	case "bottom-left":
+		y = g.viewHeight - hudMargin - h
+	case "bottom-right":
+		x, y = g.viewWidth-hudMargin-w, g.viewHeight-hudMargin-h
+	}
+
+	vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), color.RGBA{0, 0, 0, 160}, false)
+	vector.StrokeRect(screen, float32(x), float32(y), float32(w), float32(h), 1, color.White, false)
+	for i := range g.logos {
+		l := &g.logos[i]
+		// Keep the dot inside the frame, even for a logo wrapping over an edge
+		cx := math.Max(0, math.Min(l.x+l.width/2, g.width)) * scale
+		cy := math.Max(0, math.Min(l.y+l.height/2, g.height)) * scale
+		vector.DrawFilledCircle(screen, float32(x+cx), float32(y+cy), minimapDotRadius, g.logoColor(l), true)
+	}
+}
@@ -0,0 +1,86 @@
+package dvdlogo
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const obstacleScale = 2.5 // Size of the boss logo relative to the bouncing ones
+
+// obstacleColor tints the boss logo so it stands apart from the bouncing ones
+var obstacleColor = color.RGBA{128, 128, 128, 255}
+
+// rect is an axis-aligned rectangle
+type rect struct {
+	x, y, width, height float64
+}
+
+// centerObstacle keeps the boss logo in the middle of the screen
+func (g *Game) centerObstacle() {
+	if g.obstacle == nil {
+		return
+	}
+	w, h := g.drawnLogoSize()
+	g.obstacle.width = w * obstacleScale
+	g.obstacle.height = h * obstacleScale
+	g.obstacle.x = (g.width - g.obstacle.width) / 2
+	g.obstacle.y = (g.height - g.obstacle.height) / 2
+}
+
+// bounceOffObstacle pushes l out of o and reflects its velocity on the side
+// it left through. The side needing the smallest push wins, but only sides
+// that leave the whole logo on a width by height screen are considered, so a
+// logo squeezed between the obstacle and a wall gets out around the obstacle
+// instead of bouncing between the two forever.
+func (l *Logo) bounceOffObstacle(o rect, width, height float64) bool {
+	overlapX := math.Min(l.x+l.width, o.x+o.width) - math.Max(l.x, o.x)
+	overlapY := math.Min(l.y+l.height, o.y+o.height) - math.Max(l.y, o.y)
+	if overlapX <= 0 || overlapY <= 0 {
+		return false
+	}
+
+	exits := []point{
+		{o.x - l.width - l.x, 0},  // Left
+		{o.x + o.width - l.x, 0},  // Right
+		{0, o.y - l.height - l.y}, // Above
+		{0, o.y + o.height - l.y}, // Below
+	}
+	best := -1
+	for i, e := range exits {
+		x, y := l.x+e.x, l.y+e.y
+		if x < 0 || y < 0 || x+l.width > width || y+l.height > height {
+			continue
+		}
+		if best < 0 || math.Abs(e.x)+math.Abs(e.y) < math.Abs(exits[best].x)+math.Abs(exits[best].y) {
+			best = i
+		}
+	}
+	if best < 0 {
+		// The screen is too small to get around the obstacle, let the logo pass through
+		return false
+	}
+
+	e := exits[best]
+	l.x += e.x
+	l.y += e.y
+	if e.x != 0 {
+		l.velocityX = math.Copysign(l.velocityX, e.x)
+	} else {
+		l.velocityY = math.Copysign(l.velocityY, e.y)
+	}
+	return true
+}
+
+// drawObstacle draws the boss logo, upright and tinted gray
+func (g *Game) drawObstacle(screen *ebiten.Image) {
+	o := g.obstacle
+	op := &ebiten.DrawImageOptions{}
+	frame := g.logoFrame()
+	op.GeoM.Scale(o.width/float64(frame.Bounds().Dx()), o.height/float64(frame.Bounds().Dy()))
+	op.GeoM.Translate(o.x, o.y)
+	op.ColorScale.ScaleWithColor(obstacleColor)
+	op.Filter = g.logoFilter
+	screen.DrawImage(frame, op)
+}
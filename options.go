@@ -0,0 +1,323 @@
+package dvdlogo
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Options configures a Game created by NewGame. The embedded Config holds
+// the screen size, logo width, velocities, colors and the other tunables.
+type Options struct {
+	Config
+
+	Logo  image.Image // nil for the built-in DVD logo
+	Count int         // Number of logos, at least 1
+	Seed  int64       // Picks the start positions, directions and random colors
+
+	// Start position of the first logo, nil to randomize, measured from the
+	// top-left inside the margins. It must be on the screen and is moved back
+	// so the whole logo fits.
+	StartX, StartY *float64
+
+	RandomColors bool // Random logo color on each bounce instead of cycling in order
+	Rainbow      bool // Turn the logo hue smoothly around the color wheel instead of changing colors on bounces
+	Assist       bool // Steer the logos towards corners, so the hits don't count as legit
+	Gravity      bool // Pull the logos down and lose energy on floor bounces
+	Wrap         bool // Wrap the logos around to the opposite edge instead of bouncing, Asteroids style
+	Boss         bool // Put a big stationary logo in the middle
+	Screensaver  bool // Quit on the first user activity
+	Paused       bool // Open with the pause menu showing
+	Tight        bool // Bounce off the walls with the opaque part of the logo instead of the whole image
+	NoMouse      bool // Ignore the mouse buttons, for kiosks where a passerby shouldn't steer the logo
+
+	// Wobble turns the velocity by a random angle of up to this many degrees
+	// on every wall bounce, keeping the speed. 0 bounces by pure reflection.
+	Wobble float64
+
+	// DumpInterval prints the state as a JSON line to stdout every this many
+	// ticks, on top of the dump key. 0 only dumps on key.
+	DumpInterval int
+
+	// Trace steers the first logo along the letters of this word, sketching
+	// them on the screen. Only letters, digits and spaces can be traced.
+	Trace string
+
+	// Duration quits the game once the session has run this long, not
+	// counting time spent paused. 0 runs until quit.
+	Duration time.Duration
+
+	// Target turns the session into a time trial, ending it with the final
+	// time once this many corners are hit. 0 disables it.
+	Target int
+
+	// Files the game saves to, empty to disable
+	ConfigPath  string // Volume and settings menu changes, saved into what the file already holds
+	StatsPath   string // Lifetime hits and best session
+	SessionPath string // Session summary on quit
+	HeatmapPath string // PNG of where the logos spent their time, on quit
+
+	// Record receives the input of every tick, to be played back with
+	// LoadReplay and Replay, nil to disable
+	Record io.Writer
+	// Replay plays back a recorded session instead of reading the keyboard
+	// and mouse. Set Seed to the recorded one to get the same bounce path.
+	Replay *Replay
+
+	// Sound creates the audio context, of which there can only be one per
+	// process, so leave it off when embedding into a game with its own
+	Sound bool
+}
+
+// DefaultOptions returns the built-in settings with a single logo and a time based seed
+func DefaultOptions() Options {
+	return Options{
+		Config: DefaultConfig(),
+		Count:  1,
+		Seed:   time.Now().UnixNano(),
+	}
+}
+
+// LoadLogo decodes the PNG or JPEG logo image at path, the built-in logo if
+// path is empty, or an image piped into standard input if path is "-"
+func LoadLogo(path string) (image.Image, error) {
+	if path == "" {
+		img, _, err := image.Decode(bytes.NewReader(logoImageData))
+		return img, err
+	}
+	if path == "-" {
+		return loadStdinLogo()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid image: %w", path, err)
+	}
+	return img, nil
+}
+
+// loadStdinLogo decodes an image piped into standard input, after reading all
+// of it. A terminal is refused rather than waiting for an image to be typed.
+func loadStdinLogo() (image.Image, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return nil, fmt.Errorf("standard input is a terminal rather than a pipe or file, pipe an image into it to use -logo -")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("could not read standard input: %w", err)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("standard input is not a valid image: %w", err)
+	}
+	return img, nil
+}
+
+// FitScreenToLogo sizes the screen to the aspect ratio of the logo, with its
+// longer side maxSide pixels. Very wide or tall logos are capped at an aspect
+// ratio of maxFitAspect, so the logo still has room to move along both axes.
+func (o *Options) FitScreenToLogo(maxSide int) error {
+	if maxSide <= 0 {
+		return fmt.Errorf("screen size %d must be positive", maxSide)
+	}
+	logo := o.logo()
+	rects, err := logoFrameRects(logo.Bounds().Size(), o.Config)
+	if err != nil {
+		return err
+	}
+	aspect := float64(rects[0].Dx()) / float64(rects[0].Dy())
+	aspect = math.Max(1/maxFitAspect, math.Min(aspect, maxFitAspect))
+	if aspect >= 1 {
+		o.ScreenWidth, o.ScreenHeight = maxSide, int(math.Round(float64(maxSide)/aspect))
+	} else {
+		o.ScreenWidth, o.ScreenHeight = int(math.Round(float64(maxSide)*aspect)), maxSide
+	}
+	return nil
+}
+
+// ScaleLogoToScreen sets the logo width to fraction of the screen width, so
+// the logo keeps its proportion to the screen at any resolution. Its height
+// follows from the aspect ratio of the image, and Validate checks it fits.
+func (o *Options) ScaleLogoToScreen(fraction float64) error {
+	if fraction <= 0 || fraction >= 1 {
+		return fmt.Errorf("logo scale %v must be between 0 and 1", fraction)
+	}
+	o.LogoWidth = fraction * float64(o.ScreenWidth)
+	return nil
+}
+
+// Validate rejects options NewGame can't create a game from
+func (o Options) Validate() error {
+	if err := o.Config.validate(); err != nil {
+		return err
+	}
+	if _, err := parseKeyBindings(o.Keys); err != nil {
+		return fmt.Errorf("invalid key bindings: %w", err)
+	}
+	if o.Gravity && o.Wrap {
+		return fmt.Errorf("gravity and wrap can't be combined, a wrapping logo has no floor to land on")
+	}
+	if o.Trace != "" {
+		if err := validTraceText(o.Trace); err != nil {
+			return err
+		}
+		if o.Gravity {
+			return fmt.Errorf("gravity and trace can't be combined, the logo would fall off the letters")
+		}
+	}
+	if o.Wobble < 0 || o.Wobble >= maxWobble {
+		return fmt.Errorf("wobble %v must be at least 0 and below %v degrees", o.Wobble, maxWobble)
+	}
+	if o.DumpInterval < 0 {
+		return fmt.Errorf("dump interval must not be negative, got %d", o.DumpInterval)
+	}
+	if o.Duration < 0 {
+		return fmt.Errorf("duration must not be negative, got %v", o.Duration)
+	}
+	if o.Target < 0 {
+		return fmt.Errorf("target must not be negative, got %d", o.Target)
+	}
+	if o.Count < 1 {
+		return fmt.Errorf("count must be at least 1, got %d", o.Count)
+	}
+	logo := o.logo()
+	rects, err := logoFrameRects(logo.Bounds().Size(), o.Config)
+	if err != nil {
+		return err
+	}
+	innerWidth, innerHeight := o.innerSize(o.ScreenWidth, o.ScreenHeight)
+	logoHeight := o.logoHeight(rects[0])
+	if logoHeight >= float64(innerHeight) {
+		return fmt.Errorf("the logo is %.0f pixels tall at a width of %v, which doesn't fit the screen height %d inside the margins", logoHeight, o.LogoWidth, innerHeight)
+	}
+	if o.StartX != nil && (*o.StartX < 0 || *o.StartX >= float64(innerWidth)) {
+		return fmt.Errorf("x position %v is off the screen, it must be at least 0 and below %d", *o.StartX, innerWidth)
+	}
+	if o.StartY != nil && (*o.StartY < 0 || *o.StartY >= float64(innerHeight)) {
+		return fmt.Errorf("y position %v is off the screen, it must be at least 0 and below %d", *o.StartY, innerHeight)
+	}
+	return nil
+}
+
+// logo returns the logo image to use, the built-in one if none is set
+func (o Options) logo() image.Image {
+	if o.Logo != nil {
+		return o.Logo
+	}
+	return builtinLogo()
+}
+
+// logoHeight returns how tall a logo frame is when scaled to the configured width
+func (o Options) logoHeight(frame image.Rectangle) float64 {
+	return o.LogoWidth / float64(frame.Dx()) * float64(frame.Dy())
+}
+
+// NewGame creates a game from opts, to be run with ebiten.RunGame or drawn
+// from another game. It panics if the options are invalid, check them with
+// Validate first.
+func NewGame(opts Options) *Game {
+	if err := opts.Validate(); err != nil {
+		panic(err)
+	}
+	keys, _ := parseKeyBindings(opts.Keys)
+	innerWidth, innerHeight := opts.innerSize(opts.ScreenWidth, opts.ScreenHeight)
+
+	g := &Game{
+		config:      opts.Config,
+		logos:       make([]Logo, opts.Count),
+		logoChoices: newLogoChoices(opts),
+		logoFilter:  logoFilters[opts.LogoFilter],
+		tight:       opts.Tight,
+		width:       float64(innerWidth),
+		height:      float64(innerHeight),
+		viewWidth:   float64(opts.ScreenWidth),
+		viewHeight:  float64(opts.ScreenHeight),
+		keys:        keys,
+		input:       liveInput{},
+		keyState:    make(map[ebiten.Key]bool),
+		clockFace:   newClockFace(clockFontData),
+
+		backgroundColor:  resolveColor("background color", opts.BackgroundColor, defaultBackgroundColor),
+		cornerFlashColor: resolveColor("corner flash color", opts.CornerFlashColor, defaultCornerFlashColor),
+		patternColor:     resolveColor("pattern color", opts.PatternColor, defaultPatternColor),
+
+		rng:          rand.New(rand.NewSource(opts.Seed)),
+		randomColors: opts.RandomColors,
+		autoColor:    true,
+		rainbow:      opts.Rainbow,
+		streakLength: opts.StreakLength,
+		assist:       opts.Assist,
+		gravity:      opts.Gravity,
+		wrap:         opts.Wrap,
+		noMouse:      opts.NoMouse,
+		wobble:       opts.Wobble * math.Pi / 180,
+		screensaver:  opts.Screensaver,
+		dumpInterval: opts.DumpInterval,
+		target:       opts.Target,
+		duration:     opts.Duration,
+
+		configPath:  opts.ConfigPath,
+		statsPath:   opts.StatsPath,
+		sessionPath: opts.SessionPath,
+		volume:      opts.Volume,
+		muted:       opts.Muted,
+	}
+	g.useLogo(0)
+	if opts.StartX != nil {
+		x := math.Min(*opts.StartX, g.width-g.logoWidth)
+		g.startX = &x
+	}
+	if opts.StartY != nil {
+		y := math.Min(*opts.StartY, g.height-g.logoHeight)
+		g.startY = &y
+	}
+	if g.streakLength == 0 {
+		// Trails turned on in the settings menu come back at the default length
+		g.streakLength = DefaultConfig().StreakLength
+	}
+	if opts.HeatmapPath != "" {
+		g.heatmap = newHeatmap(opts.HeatmapColumns, opts.HeatmapRows, opts.HeatmapPath)
+	}
+	if opts.Replay != nil {
+		g.input = &replayInput{frames: opts.Replay.frames}
+	}
+	if opts.Record != nil {
+		g.input = newInputRecorder(g.input, opts.Record, opts.Seed, opts.ScreenWidth, opts.ScreenHeight)
+	}
+	if opts.Trace != "" {
+		g.trace = newTracer(opts.Trace)
+		g.sketch = true
+	}
+	if opts.Boss {
+		g.obstacle = &rect{}
+		g.centerObstacle()
+	}
+	if opts.StatsPath != "" {
+		stats := loadStats(opts.StatsPath)
+		g.lifetimeHits = stats.LifetimeHits
+		g.bestSession = stats.BestSession
+	}
+	if opts.Sound {
+		g.initSound()
+	}
+	g.reset()
+	// reset leaves pausedAt at the start time, so the timer stays at zero until unpaused
+	g.paused = opts.Paused
+	g.publish()
+	return g
+}
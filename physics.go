@@ -0,0 +1,144 @@
+package dvdlogo
+
+import "math"
+
+const (
+	ghostTicks = 60   // How far ahead the debug overlay predicts the logos
+	ghostAlpha = 0.25 // Opacity of the predicted logos
+
+	velocityArrowScale = 15 // Pixels of debug arrow per pixel per tick of velocity
+	velocityArrowWidth = 2
+	velocityArrowHead  = 8 // Length of the arrowhead strokes
+)
+
+// physics holds everything moving a logo depends on besides the logo itself,
+// so the same step can run on the real logos or on copies of them
+type physics struct {
+	width, height float64
+	tolerance     float64
+	assist        bool
+	gravity       bool
+	wrap          bool
+	gravityAccel  float64
+	restitution   float64
+	obstacle      *rect
+	overhang      overhang
+}
+
+// overhang is how far the rotated logo reaches past its unrotated hitbox on
+// each side, which the walls bounce it by
+type overhang struct {
+	left, top, right, bottom float64
+}
+
+// bounces tells what a logo bounced off during one tick
+type bounces struct {
+	x, y     bool    // Off a left/right or top/bottom wall
+	obstacle bool    // Off the boss logo
+	miss     float64 // How far off an exact corner a corner bounce was, in pixels
+}
+
+// physics returns the current physics settings of the game
+func (g *Game) physics() physics {
+	return physics{
+		width:        g.width,
+		height:       g.height,
+		tolerance:    g.config.CornerTolerance,
+		assist:       g.assist,
+		gravity:      g.gravity,
+		wrap:         g.wrap,
+		gravityAccel: g.config.Gravity,
+		restitution:  g.config.Restitution,
+		obstacle:     g.obstacle,
+		overhang:     g.rotatedOverhang(),
+	}
+}
+
+// rotatedOverhang returns how far the bounding box of the hitbox, rotated with
+// the drawn logo around its center, reaches past the unrotated hitbox. Along
+// an axis the box doesn't fit on the screen, and along y with gravity so a
+// logo resting on the floor stays put, the unrotated hitbox bounces instead.
+func (g *Game) rotatedOverhang() overhang {
+	if g.rotation == 0 {
+		return overhang{}
+	}
+	// The hitbox edges, from the center of the drawn logo
+	w, h := g.drawnLogoSize()
+	left := float64(g.hitbox.Min.X)*g.logoScale - w/2
+	top := float64(g.hitbox.Min.Y)*g.logoScale - h/2
+	right, bottom := left+g.logoWidth, top+g.logoHeight
+
+	sin, cos := math.Sincos(g.rotation)
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, c := range [][2]float64{{left, top}, {right, top}, {left, bottom}, {right, bottom}} {
+		x, y := c[0]*cos-c[1]*sin, c[0]*sin+c[1]*cos
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	var o overhang
+	if maxX-minX <= g.width {
+		o.left, o.right = left-minX, maxX-right
+	}
+	if maxY-minY <= g.height && !g.gravity {
+		o.top, o.bottom = top-minY, maxY-bottom
+	}
+	return o
+}
+
+// advance moves l by one tick of dt seconds, bouncing it off the walls, or
+// wrapping it around them in wrap mode, and off the obstacle. It only changes
+// l, the scoring and effects are up to the caller.
+func (p physics) advance(l *Logo, dt float64) bounces {
+	if p.assist {
+		l.steerToCorner(p.width, p.height, assistMaxTurn*dt*referenceTPS)
+	}
+	if p.gravity {
+		l.applyGravity(p.gravityAccel, p.height, dt)
+	}
+	l.move(dt)
+
+	// Check for collision with window borders. Wrapping never bounces, so
+	// there are no corner hits in wrap mode.
+	var b bounces
+	if p.wrap {
+		l.wrapAround(p.width, p.height)
+	} else {
+		b.x, b.y, b.miss = p.bounceOffWalls(l)
+	}
+	if p.gravity && b.y && l.velocityY < 0 {
+		l.dampFloorBounce(p.height, p.restitution)
+	}
+
+	// Bounce off the boss logo like off a wall
+	if p.obstacle != nil {
+		b.obstacle = l.bounceOffObstacle(*p.obstacle, p.width, p.height)
+	}
+	return b
+}
+
+// bounceOffWalls bounces l off the walls by its rotated bounding box, the
+// hitbox grown by the overhang
+func (p physics) bounceOffWalls(l *Logo) (bouncedX, bouncedY bool, miss float64) {
+	o, width, height := p.overhang, l.width, l.height
+	vx, vy := l.velocityX, l.velocityY
+	l.x, l.y = l.x-o.left, l.y-o.top
+	l.width, l.height = width+o.left+o.right, height+o.top+o.bottom
+	bouncedX, bouncedY, miss = l.bounceOffWalls(p.width, p.height, p.tolerance)
+	l.x, l.y = l.x+o.left, l.y+o.top
+	l.width, l.height = width, height
+	// A slow logo turning next to a wall can swing its box into it while
+	// heading away. That pushes it off the wall, but isn't a bounce.
+	return bouncedX && l.velocityX != vx, bouncedY && l.velocityY != vy, miss
+}
+
+// predict returns where l will be after ticks ticks of dt seconds, without
+// changing l. Collisions with other logos and the corner speed-up aren't
+// included, so it's only exact for a single logo with the speed-up off.
+func (p physics) predict(l Logo, ticks int, dt float64) Logo {
+	n := substeps(dt)
+	for range ticks * n {
+		p.advance(&l, dt/float64(n))
+	}
+	return l
+}
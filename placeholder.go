@@ -0,0 +1,90 @@
+package dvdlogo
+
+import (
+	"image"
+	"image/color"
+	"log"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	placeholderWidth     = 160
+	placeholderHeight    = 80
+	placeholderRadius    = 16 // Corner radius of the rounded rectangle
+	placeholderTextScale = 4  // The bitmap font is scaled up this much
+)
+
+// placeholderLogo draws a DVD badge, for when no logo image can be decoded
+func placeholderLogo() image.Image {
+	return badgeLogo("DVD")
+}
+
+// badgeLogo draws a white rounded rectangle with str cut out of it, which
+// tints like the real logo does. str must be short enough to fit, about
+// five letters.
+func badgeLogo(str string) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, placeholderWidth, placeholderHeight))
+	for y := range placeholderHeight {
+		for x := range placeholderWidth {
+			if insideRoundedRect(float64(x)+0.5, float64(y)+0.5, placeholderWidth, placeholderHeight, placeholderRadius) {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+
+	// Render the text at the bitmap font size, then cut it out scaled up
+	face := basicfont.Face7x13
+	mask := image.NewAlpha(image.Rect(0, 0, font.MeasureString(face, str).Ceil(), face.Height))
+	d := font.Drawer{Dst: mask, Src: image.Opaque, Face: face, Dot: fixed.P(0, face.Ascent)}
+	d.DrawString(str)
+	offsetX := (placeholderWidth - mask.Rect.Dx()*placeholderTextScale) / 2
+	offsetY := (placeholderHeight - mask.Rect.Dy()*placeholderTextScale) / 2
+	for y := range img.Rect.Dy() {
+		for x := range img.Rect.Dx() {
+			mx, my := (x-offsetX)/placeholderTextScale, (y-offsetY)/placeholderTextScale
+			if x >= offsetX && y >= offsetY && mask.AlphaAt(mx, my).A > 0 {
+				img.Set(x, y, color.Transparent)
+			}
+		}
+	}
+	return img
+}
+
+// insideRoundedRect reports whether x, y is inside a width x height rectangle
+// at the origin with corners rounded to radius
+func insideRoundedRect(x, y, width, height, radius float64) bool {
+	// Distance past the straight part of the edges, only non-zero in a corner
+	dx := math.Max(0, math.Max(radius-x, x-(width-radius)))
+	dy := math.Max(0, math.Max(radius-y, y-(height-radius)))
+	return dx*dx+dy*dy <= radius*radius
+}
+
+// builtinLogo decodes the embedded logo, drawing a placeholder instead if
+// that fails, which only happens with a broken build
+func builtinLogo() image.Image {
+	img, err := LoadLogo("")
+	if err != nil {
+		log.Printf("Could not decode the built-in logo: %v, using a placeholder instead", err)
+		return placeholderLogo()
+	}
+	return img
+}
+
+// LogoOrFallback loads the logo at path like LoadLogo, falling back to the
+// built-in logo, and from there to a placeholder, with a warning for each.
+// It never fails, so there is always a logo to bounce.
+func LogoOrFallback(path string) image.Image {
+	if path == "" {
+		return builtinLogo()
+	}
+	img, err := LoadLogo(path)
+	if err != nil {
+		log.Printf("Could not load logo: %v, using the built-in logo instead", err)
+		return builtinLogo()
+	}
+	return img
+}
@@ -0,0 +1,44 @@
+package dvdlogo
+
+import (
+	"image/color"
+	"math"
+)
+
+// hsvToRGB turns a hue in degrees and a saturation and value from 0 to 1
+// into an opaque color, as the standard library only converts to YCbCr
+func hsvToRGB(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := v - c
+	return color.RGBA{uint8(math.Round((r + m) * 255)), uint8(math.Round((g + m) * 255)), uint8(math.Round((b + m) * 255)), 255}
+}
+
+// logoColor returns the tint of l. In rainbow mode that's the hue going
+// around the color wheel, shifted by the palette color of l, so several
+// logos keep apart and [ and ] still change it.
+func (g *Game) logoColor(l *Logo) color.RGBA {
+	if !g.rainbow {
+		return logoPalette[l.colorIndex]
+	}
+	return hsvToRGB(g.hue+360*float64(l.colorIndex)/float64(len(logoPalette)), 1, 1)
+}
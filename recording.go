@@ -0,0 +1,135 @@
+package dvdlogo
+
+import (
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	recordFrameInterval = 50 * time.Millisecond // 20 GIF frames per second
+	recordMaxFrames     = 600                   // Stop on its own after 30 seconds
+	recordScale         = 2                     // Frames are stored at half the screen size
+)
+
+// recording holds the GIF frames captured while recording is on. Frames are
+// downscaled and quantized to the web-safe palette as they come in, so a long
+// recording stays around 70MB at the default screen size.
+type recording struct {
+	frames   []*image.Paletted
+	delays   []int // Hundredths of a second each frame is shown
+	lastAt   time.Time
+	pixels   []byte // Reused buffer for reading back the screen
+	finished bool   // Hit recordMaxFrames, waiting to be saved
+}
+
+// toggleRecording starts a GIF recording, or stops and saves the current one
+func (g *Game) toggleRecording() {
+	if g.recording == nil {
+		if inBrowser {
+			log.Printf("GIF recordings can't be saved in the browser")
+			return
+		}
+		g.recording = &recording{}
+		log.Printf("Recording GIF, press G again to stop")
+		return
+	}
+	g.stopRecording()
+}
+
+// stopRecording encodes the recorded frames to a timestamped GIF in the background
+func (g *Game) stopRecording() {
+	r := g.recording
+	g.recording = nil
+	if r == nil || len(r.frames) == 0 {
+		return
+	}
+	// The last frame is shown as long as the ones before it
+	r.delays = append(r.delays, int(recordFrameInterval/(10*time.Millisecond)))
+
+	name := time.Now().Format("dvdlogo-20060102-150405.gif")
+	go func() {
+		if err := writeGIF(name, r.frames, r.delays); err != nil {
+			log.Printf("Could not save recording: %v", err)
+			return
+		}
+		log.Printf("Saved %d frame recording to %s", len(r.frames), name)
+	}()
+}
+
+// captureFrame adds the screen to the recording once every recordFrameInterval
+func (g *Game) captureFrame(screen *ebiten.Image) {
+	r := g.recording
+	if r.finished {
+		g.stopRecording()
+		return
+	}
+	now := time.Now()
+	if !r.lastAt.IsZero() && now.Sub(r.lastAt) < recordFrameInterval {
+		return
+	}
+	if !r.lastAt.IsZero() {
+		r.delays = append(r.delays, int(now.Sub(r.lastAt)/(10*time.Millisecond)))
+	}
+	r.lastAt = now
+
+	b := screen.Bounds()
+	if n := 4 * b.Dx() * b.Dy(); len(r.pixels) != n {
+		r.pixels = make([]byte, n)
+	}
+	screen.ReadPixels(r.pixels)
+	r.frames = append(r.frames, quantizeFrame(r.pixels, b.Dx(), b.Dy()))
+	if len(r.frames) >= recordMaxFrames {
+		log.Printf("Recording reached %d frames, stopping", recordMaxFrames)
+		r.finished = true
+	}
+}
+
+// quantizeFrame downscales RGBA pixels by recordScale and maps them to the
+// nearest color in the web-safe palette, which is cheap enough to do per frame
+func quantizeFrame(pix []byte, width, height int) *image.Paletted {
+	frame := image.NewPaletted(image.Rect(0, 0, width/recordScale, height/recordScale), palette.WebSafe)
+	level := func(v byte) uint8 {
+		return uint8((int(v) + 0x19) / 0x33)
+	}
+	for y := 0; y < frame.Rect.Dy(); y++ {
+		for x := 0; x < frame.Rect.Dx(); x++ {
+			i := 4 * (y*recordScale*width + x*recordScale)
+			frame.Pix[y*frame.Stride+x] = 36*level(pix[i]) + 6*level(pix[i+1]) + level(pix[i+2])
+		}
+	}
+	return frame
+}
+
+// writeGIF encodes the frames as a looping animated GIF file at path. Frames
+// captured before a window resize keep their own size inside the largest one.
+func writeGIF(path string, frames []*image.Paletted, delays []int) error {
+	var bounds image.Rectangle
+	for _, frame := range frames {
+		bounds = bounds.Union(frame.Rect)
+	}
+	anim := &gif.GIF{
+		Image: frames,
+		Delay: delays,
+		Config: image.Config{
+			ColorModel: frames[0].Palette,
+			Width:      bounds.Dx(),
+			Height:     bounds.Dy(),
+		},
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, anim); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,154 @@
+package dvdlogo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// replayHeader is the first line of a replay file, followed by one
+// inputFrame per line for every tick
+type replayHeader struct {
+	Seed int64 `json:"seed"`
+}
+
+// Replay is a recorded session, loaded with LoadReplay. Played back with
+// the same seed, config and flags, it takes the exact same bounce path.
+type Replay struct {
+	Seed   int64 // Seed the session was recorded with
+	frames []inputFrame
+}
+
+// LoadReplay reads a replay file written with Options.Record
+func LoadReplay(path string) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// The lines are small, but allow for a lot of held keys
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	var header replayHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("%s is not a valid replay: %w", path, err)
+	}
+	replay := &Replay{Seed: header.Seed}
+	for line := 2; scanner.Scan(); line++ {
+		var frame inputFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		replay.frames = append(replay.frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// replayInput plays back the frames of a replay in place of the real input
+type replayInput struct {
+	frames []inputFrame
+	frame  inputFrame // The current tick, zero before the first one
+}
+
+func (r *replayInput) next() bool {
+	if len(r.frames) == 0 {
+		return false
+	}
+	r.frame, r.frames = r.frames[0], r.frames[1:]
+	return true
+}
+
+func (r *replayInput) isKeyPressed(key ebiten.Key) bool { return r.frame.isKeyPressed(key) }
+
+func (r *replayInput) isMouseButtonPressed(button ebiten.MouseButton) bool {
+	return r.frame.isMouseButtonPressed(button)
+}
+
+func (r *replayInput) cursorPosition() (int, int) { return r.frame.X, r.frame.Y }
+
+func (r *replayInput) wheel() (float64, float64) { return r.frame.WheelX, r.frame.WheelY }
+
+func (r *replayInput) isFocused() bool { return !r.frame.Blurred }
+
+func (r *replayInput) isWindowBeingClosed() bool { return r.frame.Closed }
+
+// screenSize keeps the recorded size whatever the window is, the screen is
+// scaled to fit instead
+func (r *replayInput) screenSize(outsideWidth, outsideHeight int) (int, int) {
+	if r.frame.Width == 0 {
+		// Before the first tick
+		return outsideWidth, outsideHeight
+	}
+	return r.frame.Width, r.frame.Height
+}
+
+// inputRecorder passes another input through, writing every tick of it to
+// a replay file. Every query of a tick is answered from the written frame,
+// so the game sees exactly what a replay of it will.
+type inputRecorder struct {
+	in            input
+	enc           *json.Encoder // nil once writing failed
+	frame         inputFrame
+	width, height int // Last screen size, recorded with the next tick
+}
+
+// newInputRecorder starts a replay file on w, recorded with seed
+func newInputRecorder(in input, w io.Writer, seed int64, width, height int) *inputRecorder {
+	r := &inputRecorder{in: in, enc: json.NewEncoder(w), width: width, height: height}
+	r.write(replayHeader{Seed: seed})
+	return r
+}
+
+func (r *inputRecorder) next() bool {
+	if !r.in.next() {
+		return false
+	}
+	r.frame = capture(r.in, r.width, r.height)
+	r.write(r.frame)
+	return true
+}
+
+// write adds a line to the replay file, giving up on the first error
+func (r *inputRecorder) write(v any) {
+	if r.enc == nil {
+		return
+	}
+	if err := r.enc.Encode(v); err != nil {
+		log.Printf("Could not record input, stopping the recording: %v", err)
+		r.enc = nil
+	}
+}
+
+func (r *inputRecorder) isKeyPressed(key ebiten.Key) bool { return r.frame.isKeyPressed(key) }
+
+func (r *inputRecorder) isMouseButtonPressed(button ebiten.MouseButton) bool {
+	return r.frame.isMouseButtonPressed(button)
+}
+
+func (r *inputRecorder) cursorPosition() (int, int) { return r.frame.X, r.frame.Y }
+
+func (r *inputRecorder) wheel() (float64, float64) { return r.frame.WheelX, r.frame.WheelY }
+
+func (r *inputRecorder) isFocused() bool { return !r.frame.Blurred }
+
+func (r *inputRecorder) isWindowBeingClosed() bool { return r.frame.Closed }
+
+func (r *inputRecorder) screenSize(outsideWidth, outsideHeight int) (int, int) {
+	r.width, r.height = r.in.screenSize(outsideWidth, outsideHeight)
+	return r.width, r.height
+}
@@ -0,0 +1,51 @@
+package dvdlogo
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	screensaverGraceTicks = 30 // Ticks after startup where the cursor may still settle
+	screensaverMoveLimit  = 8  // Pixels the cursor may drift before it counts as activity
+)
+
+// userActive reports whether there was any key press, click, scroll or cursor
+// movement since startup, which ends the screensaver. Right after startup the
+// cursor position isn't reliable yet, so it's recorded again on every tick of
+// the grace period instead of being compared. A key or button still held
+// from launch, past the grace period, only counts once it's pressed again.
+// The input is read like the rest of the game reads it, so a replay of a
+// screensaver session ends on the same tick.
+func (g *Game) userActive() bool {
+	var held inputFrame
+	pressed := false
+	for key := ebiten.Key(0); key <= ebiten.KeyMax; key++ {
+		if g.input.isKeyPressed(key) {
+			held.Keys = append(held.Keys, key)
+			pressed = pressed || !g.screensaverHeld.isKeyPressed(key)
+		}
+	}
+	for b := ebiten.MouseButton0; b <= ebiten.MouseButtonMax; b++ {
+		if g.input.isMouseButtonPressed(b) {
+			held.Buttons = append(held.Buttons, b)
+			pressed = pressed || !g.screensaverHeld.isMouseButtonPressed(b)
+		}
+	}
+	g.screensaverHeld = held
+
+	x, y := g.input.cursorPosition()
+	if g.screensaverTicks < screensaverGraceTicks {
+		g.screensaverTicks++
+		g.startCursorX, g.startCursorY = x, y
+		return false
+	}
+	if pressed {
+		return true
+	}
+	if dx, dy := g.input.wheel(); dx != 0 || dy != 0 {
+		return true
+	}
+	return math.Hypot(float64(x-g.startCursorX), float64(y-g.startCursorY)) > screensaverMoveLimit
+}
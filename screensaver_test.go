@@ -0,0 +1,68 @@
+package dvdlogo
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestScreensaverEndsOnActivity(t *testing.T) {
+	still := inputFrame{Width: testWidth, Height: testHeight, X: 100, Y: 100}
+	drifted := still
+	drifted.X += screensaverMoveLimit
+	moved := still
+	moved.X += screensaverMoveLimit + 1
+	key := still
+	key.Keys = []ebiten.Key{ebiten.KeyJ}
+	click := still
+	click.Buttons = []ebiten.MouseButton{ebiten.MouseButtonLeft}
+	scroll := still
+	scroll.WheelY = -1
+
+	tests := []struct {
+		name     string
+		held     bool // Hold the activity from launch on
+		activity inputFrame
+		ends     bool
+	}{
+		{"key", false, key, true},
+		{"click", false, click, true},
+		{"scroll", false, scroll, true},
+		{"cursor moved", false, moved, true},
+		{"cursor drifted", false, drifted, false},
+		{"key held from launch", true, key, false},
+		{"click held from launch", true, click, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Activity during the grace period doesn't count
+			frames := []inputFrame{key, moved}
+			for len(frames) < screensaverGraceTicks {
+				frames = append(frames, still)
+			}
+			if tt.held {
+				for i := range frames {
+					frames[i] = tt.activity
+				}
+			}
+			frames = append(frames, tt.activity, still)
+			opts := DefaultOptions()
+			opts.Screensaver = true
+			opts.Replay = &Replay{frames: frames}
+			g := NewGame(opts)
+
+			ticks := 0
+			for g.Update() == nil {
+				ticks++
+			}
+			// Without activity the session runs until the replay runs out
+			want := len(frames)
+			if tt.ends {
+				want = screensaverGraceTicks
+			}
+			if ticks != want {
+				t.Errorf("ended after %d ticks, want %d", ticks, want)
+			}
+		})
+	}
+}
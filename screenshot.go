@@ -0,0 +1,40 @@
+package dvdlogo
+
+import (
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// takeScreenshot copies the screen pixels and writes them to a timestamped
+// PNG in the background, so encoding doesn't stall the game loop
+func takeScreenshot(screen *ebiten.Image) {
+	img := image.NewRGBA(screen.Bounds())
+	screen.ReadPixels(img.Pix)
+
+	name := time.Now().Format("dvdlogo-20060102-150405.000.png")
+	go func() {
+		if err := writePNG(name, img); err != nil {
+			log.Printf("Could not save screenshot: %v", err)
+			return
+		}
+		log.Printf("Saved screenshot to %s", name)
+	}()
+}
+
+// writePNG encodes img as a PNG file at path
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
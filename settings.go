@@ -0,0 +1,100 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Rows of the settings menu, in the order they're shown
+const (
+	settingSpeed = iota
+	settingTrails
+	settingSound
+	settingVolume
+	settingCount
+)
+
+// handleSettingsKeys moves the highlight with up/down and changes the
+// highlighted setting with left/right while the settings menu is open
+func (g *Game) handleSettingsKeys() {
+	if g.keyJustPressed(ebiten.KeyArrowUp) {
+		g.menuIndex = (g.menuIndex + settingCount - 1) % settingCount
+	}
+	if g.keyJustPressed(ebiten.KeyArrowDown) {
+		g.menuIndex = (g.menuIndex + 1) % settingCount
+	}
+	// Check both keys, so neither misses its keyState update
+	left := g.keyJustPressed(ebiten.KeyArrowLeft)
+	right := g.keyJustPressed(ebiten.KeyArrowRight)
+	if left == right {
+		return
+	}
+	switch g.menuIndex {
+	case settingSpeed:
+		if right {
+			g.scaleSpeed(speedUpFactor)
+		} else {
+			g.scaleSpeed(slowDownFactor)
+		}
+	case settingTrails:
+		if g.config.StreakLength > 0 {
+			g.streakLength = g.config.StreakLength
+			g.config.StreakLength = 0
+		} else {
+			g.config.StreakLength = g.streakLength
+		}
+		g.settingsChanged = true
+	case settingSound:
+		g.muted = !g.muted
+		g.settingsChanged = true
+	case settingVolume:
+		// changeVolume saves the volume right away, like + and - do
+		if right {
+			g.changeVolume(volumeStep)
+		} else {
+			g.changeVolume(-volumeStep)
+		}
+	}
+}
+
+// onOff formats a setting that is either on or off
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// drawSettings shows the settings menu with the selected row highlighted
+func (g *Game) drawSettings(screen *ebiten.Image) {
+	rows := [settingCount]string{
+		settingSpeed:  fmt.Sprintf("Max speed: %.1f", max(g.maxVelocityX, g.maxVelocityY)),
+		settingTrails: "Trails: " + onOff(g.config.StreakLength > 0),
+		settingSound:  "Sound: " + onOff(!g.muted),
+		settingVolume: fmt.Sprintf("Volume: %.0f%%", g.volume*100),
+	}
+	lines := append([]string{"SETTINGS"}, rows[:]...)
+	lines = append(lines, fmt.Sprintf("[S] Back  [%s] Continue", g.keys[actionContinue]))
+	// The first line is the title, so the rows start at the second one
+	g.drawMenu(screen, lines, g.menuIndex+1)
+}
+
+// saveSettings writes the trails and sound settings into the config file,
+// leaving the rest of the file as it was. The speed only applies to this
+// session, the config keeps the start speed.
+func (g *Game) saveSettings() {
+	if !g.settingsChanged {
+		return
+	}
+	g.settingsChanged = false
+	g.config.Muted = g.muted
+	if g.configPath == "" {
+		return
+	}
+	changes := map[string]any{"streakLength": g.config.StreakLength, "muted": g.muted}
+	if err := updateConfig(g.configPath, changes); err != nil {
+		log.Printf("Could not save settings: %v", err)
+	}
+}
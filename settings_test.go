@@ -0,0 +1,53 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestSaveSettingsSavesOnlyTheMenuSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"backgroundColor": "#102030", "streakLength": 4}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	opts.Config = cfg
+	opts.ConfigPath = path
+	// Like a -bounce-jitter flag, which must not end up in the file
+	opts.BounceJitter = 0.3
+	g := NewGame(opts)
+	g.menuIndex = settingTrails
+	g.input = &replayInput{frames: []inputFrame{{Width: testWidth, Height: testHeight, Keys: []ebiten.Key{ebiten.KeyArrowRight}}}}
+	g.input.next()
+	g.handleSettingsKeys()
+	g.muted = true
+	g.saveSettings()
+
+	saved, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.StreakLength != 0 || !saved.Muted {
+		t.Errorf("saved trails of %d and muted %v, want trails off and muted", saved.StreakLength, saved.Muted)
+	}
+	if saved.BackgroundColor != "#102030" {
+		t.Errorf("saved background color %q, want the file's #102030", saved.BackgroundColor)
+	}
+	if saved.BounceJitter != 0 {
+		t.Errorf("saved bounce jitter %v, want the default 0", saved.BounceJitter)
+	}
+	// Settings the file leaves out must keep following the defaults
+	var settings map[string]json.RawMessage
+	readJSON(t, path, &settings)
+	if len(settings) != 3 {
+		t.Errorf("saved %d settings, want just the file's background color and the trails and muted settings", len(settings))
+	}
+}
@@ -0,0 +1,16 @@
+package dvdlogo
+
+import "math/rand"
+
+// shakeOffset returns how far the scene is moved this frame by the corner
+// hit shake. It shrinks linearly to exactly zero over shakeFrames ticks, and
+// holds still while paused so the menu doesn't jitter.
+func (g *Game) shakeOffset() (float64, float64) {
+	if g.shakeFrames == 0 || g.paused || g.finished {
+		return 0, 0
+	}
+	// The global source keeps the seeded one, and with it the bounce path, the
+	// same however often frames are drawn
+	m := g.config.ShakeMagnitude * float64(g.shakeFrames) / float64(g.config.ShakeFrames)
+	return m * (2*rand.Float64() - 1), m * (2*rand.Float64() - 1)
+}
@@ -0,0 +1,50 @@
+package dvdlogo
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const sketchLineWidth = 2
+
+// sketchPaths extends the permanent line behind every logo from where it was
+// before this tick's move to where it is now. A logo whose trail was just
+// cleared, because it was respawned or clamped into a resized window, has no
+// previous position, so no line is drawn across the screen to its new spot.
+// A logo tracing a -trace word only draws while its pen is down.
+func (g *Game) sketchPaths() {
+	g.ensureCanvas()
+	for i := range g.logos {
+		l := &g.logos[i]
+		if l.trail.count == 0 || i == 0 && g.trace != nil && !g.trace.penDown() {
+			continue
+		}
+		from := l.trail.at(l.trail.count - 1)
+		vector.StrokeLine(g.canvas,
+			float32(from.x+l.width/2), float32(from.y+l.height/2),
+			float32(l.x+l.width/2), float32(l.y+l.height/2),
+			sketchLineWidth, g.logoColor(l), true)
+	}
+}
+
+// ensureCanvas makes the sketch canvas match the screen size, keeping what
+// was drawn so far when the window is resized
+func (g *Game) ensureCanvas() {
+	w, h := int(g.width), int(g.height)
+	if g.canvas != nil && g.canvas.Bounds().Dx() == w && g.canvas.Bounds().Dy() == h {
+		return
+	}
+	canvas := ebiten.NewImage(w, h)
+	if g.canvas != nil {
+		canvas.DrawImage(g.canvas, nil)
+		g.canvas.Deallocate()
+	}
+	g.canvas = canvas
+}
+
+// clearSketch wipes the sketch canvas
+func (g *Game) clearSketch() {
+	if g.canvas != nil {
+		g.canvas.Clear()
+	}
+}
@@ -0,0 +1,94 @@
+package dvdlogo
+
+import (
+	"bytes"
+	_ "embed"
+	"log"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+var (
+	//go:embed corner.wav
+	cornerSoundData []byte // Embedded the corner hit chime
+
+	//go:embed bounce.wav
+	bounceSoundData []byte // Embedded the wall bounce tick
+)
+
+const (
+	sampleRate = 44100
+	volumeStep = 0.1
+)
+
+// newSoundPlayer decodes an embedded wav sound into a player on ctx
+func newSoundPlayer(ctx *audio.Context, data []byte) (*audio.Player, error) {
+	stream, err := wav.DecodeWithSampleRate(ctx.SampleRate(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return ctx.NewPlayer(stream)
+}
+
+// applyVolume sets the player volumes from the volume and mute settings. It
+// runs every tick, so muting also silences a sound that is already playing.
+func (g *Game) applyVolume() {
+	setVolume(g.cornerPlayer, g.volume, g.muted)
+	setVolume(g.bouncePlayer, g.volume, g.muted || g.bounceMuted)
+	setVolume(g.chimePlayer, g.volume, g.muted)
+}
+
+// setVolume sets the volume of p, or silences it when muted
+func setVolume(p *audio.Player, volume float64, muted bool) {
+	if p == nil {
+		return
+	}
+	if muted {
+		p.SetVolume(0)
+	} else {
+		p.SetVolume(volume)
+	}
+}
+
+// changeVolume adjusts the volume by delta and remembers it in the config
+// file, leaving the rest of the file as it was
+func (g *Game) changeVolume(delta float64) {
+	g.volume = math.Max(0, math.Min(g.volume+delta, 1))
+	g.config.Volume = g.volume
+	if g.configPath == "" {
+		return
+	}
+	if err := updateConfig(g.configPath, map[string]any{"volume": g.volume}); err != nil {
+		log.Printf("Could not save volume: %v", err)
+	}
+}
+
+// playSound restarts p from the beginning so repeated hits re-trigger a
+// single player instead of stacking overlapping sounds
+func playSound(p *audio.Player) {
+	if p == nil {
+		return
+	}
+	if err := p.Rewind(); err != nil {
+		log.Printf("Could not rewind sound: %v", err)
+		return
+	}
+	p.Play()
+}
+
+// initSound creates the audio context and the corner, bounce and milestone sound players
+func (g *Game) initSound() {
+	var err error
+	g.audioContext = audio.NewContext(sampleRate)
+	g.cornerPlayer, err = newSoundPlayer(g.audioContext, cornerSoundData)
+	if err != nil {
+		log.Printf("Could not load corner sound: %v", err)
+	}
+	g.bouncePlayer, err = newSoundPlayer(g.audioContext, bounceSoundData)
+	if err != nil {
+		log.Printf("Could not load bounce sound: %v", err)
+	}
+	g.chimePlayer = g.audioContext.NewPlayerFromBytes(chimeSound())
+}
@@ -0,0 +1,46 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestChangeVolumeSavesOnlyTheVolume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"backgroundColor": "#102030", "volume": 0.5}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	opts.Config = cfg
+	opts.ConfigPath = path
+	// Like a -flash-color flag, which must not end up in the file
+	opts.CornerFlashColor = "#ff0000"
+	g := NewGame(opts)
+	g.changeVolume(-volumeStep)
+
+	saved, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Volume != g.volume {
+		t.Errorf("saved volume %v, want %v", saved.Volume, g.volume)
+	}
+	if saved.BackgroundColor != "#102030" {
+		t.Errorf("saved background color %q, want the file's #102030", saved.BackgroundColor)
+	}
+	if saved.CornerFlashColor != defaultCornerFlashColor {
+		t.Errorf("saved corner flash color %q, want the default %q", saved.CornerFlashColor, defaultCornerFlashColor)
+	}
+	// Settings the file leaves out must keep following the defaults
+	var settings map[string]json.RawMessage
+	readJSON(t, path, &settings)
+	if len(settings) != 2 {
+		t.Errorf("saved %d settings, want just the file's background color and volume", len(settings))
+	}
+}
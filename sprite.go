@@ -0,0 +1,120 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// logoFrameRects slices a sprite sheet of the given size into the configured
+// number of frames, read left to right and top to bottom. A still logo is a
+// single frame covering the whole image.
+func logoFrameRects(size image.Point, cfg Config) ([]image.Rectangle, error) {
+	w, h := cfg.LogoFrameWidth, cfg.LogoFrameHeight
+	if w == 0 {
+		w = size.X
+	}
+	if h == 0 {
+		h = size.Y
+	}
+	if size.X%w != 0 || size.Y%h != 0 {
+		return nil, fmt.Errorf("the %dx%d logo sheet can't be split into %dx%d frames, its size must be a multiple of the frame size", size.X, size.Y, w, h)
+	}
+	cols, rows := size.X/w, size.Y/h
+	if cfg.LogoFrames > cols*rows {
+		return nil, fmt.Errorf("the %dx%d logo sheet only has room for %d frames of %dx%d, not %d", size.X, size.Y, cols*rows, w, h, cfg.LogoFrames)
+	}
+
+	rects := make([]image.Rectangle, cfg.LogoFrames)
+	for i := range rects {
+		x, y := i%cols*w, i/cols*h
+		rects[i] = image.Rect(x, y, x+w, y+h)
+	}
+	return rects, nil
+}
+
+// opaqueBounds returns the smallest rectangle, relative to the top-left of a
+// frame, covering every pixel that isn't fully transparent in any of the
+// frames, so the animation doesn't change the hitbox. A fully transparent
+// logo gets the whole frame.
+func opaqueBounds(img image.Image, rects []image.Rectangle) image.Rectangle {
+	var bounds image.Rectangle
+	origin := img.Bounds().Min
+	for _, r := range rects {
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if _, _, _, a := img.At(origin.X+x, origin.Y+y).RGBA(); a == 0 {
+					continue
+				}
+				p := image.Pt(x, y).Sub(r.Min)
+				bounds = bounds.Union(image.Rectangle{p, p.Add(image.Pt(1, 1))})
+			}
+		}
+	}
+	if bounds.Empty() {
+		return image.Rect(0, 0, rects[0].Dx(), rects[0].Dy())
+	}
+	return bounds
+}
+
+// logoFilters maps the logoFilter config names to the filters the logo is
+// scaled with. Nearest keeps the hard edges of pixel art, linear smooths
+// photos and scaled down logos.
+var logoFilters = map[string]ebiten.Filter{
+	"linear":  ebiten.FilterLinear,
+	"nearest": ebiten.FilterNearest,
+}
+
+// validLogoFilter rejects a filter that isn't one of logoFilters
+func validLogoFilter(name string) error {
+	if _, ok := logoFilters[name]; !ok {
+		return fmt.Errorf("logoFilter %q must be linear or nearest", name)
+	}
+	return nil
+}
+
+// sliceFrames cuts the frames out of the sheet, without copying any pixels
+func sliceFrames(sheet *ebiten.Image, rects []image.Rectangle) []*ebiten.Image {
+	frames := make([]*ebiten.Image, len(rects))
+	for i, r := range rects {
+		frames[i] = sheet.SubImage(r).(*ebiten.Image)
+	}
+	return frames
+}
+
+// cropImage returns the part r of img, where r starts at the top-left of img
+func cropImage(img image.Image, r image.Rectangle) image.Image {
+	r = r.Add(img.Bounds().Min)
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(r)
+	}
+	cropped := image.NewRGBA(r)
+	draw.Draw(cropped, r, img, r.Min, draw.Src)
+	return cropped
+}
+
+// logoFrame returns the frame of the logo to show at the current point of
+// the session, so the animation pauses along with the timer
+func (g *Game) logoFrame() *ebiten.Image {
+	if len(g.logoFrames) == 1 {
+		return g.logoFrames[0]
+	}
+	i := int(g.elapsed().Seconds()*g.config.LogoFrameRate) % len(g.logoFrames)
+	return g.logoFrames[i]
+}
+
+// drawnLogoSize returns the size of a whole logo frame on screen, which is
+// larger than the logos' hitbox if it's cut down to the opaque pixels
+func (g *Game) drawnLogoSize() (float64, float64) {
+	frame := g.logoFrames[0].Bounds()
+	return float64(frame.Dx()) * g.logoScale, float64(frame.Dy()) * g.logoScale
+}
+
+// Icon returns the first frame of the current logo, to be used with ebiten.SetWindowIcon
+func (g *Game) Icon() image.Image {
+	return g.icon
+}
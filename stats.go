@@ -0,0 +1,48 @@
+package dvdlogo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// savedStats is the data persisted between runs
+type savedStats struct {
+	LifetimeHits int `json:"lifetimeHits"`
+	BestSession  int `json:"bestSession"` // Most corner hits in a single session
+}
+
+// sessionStats summarizes a single run, written once when the game quits
+type sessionStats struct {
+	CornerHits      int     `json:"cornerHits"`
+	Corners         [4]int  `json:"corners"` // Top-left, top-right, bottom-left and bottom-right
+	PerfectCorners  int     `json:"perfectCorners"`
+	WallBounces     int     `json:"wallBounces"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	MaxSpeed        float64 `json:"maxSpeed"`
+}
+
+// loadStats reads the saved stats, starting from zero if the file is missing or corrupt
+func loadStats(path string) savedStats {
+	var stats savedStats
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return savedStats{}
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return savedStats{}
+	}
+	return stats
+}
+
+// saveStats writes the stats to path, creating its directory if needed
+func saveStats(path string, stats any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,160 @@
+package dvdlogo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	traceSpeed     = 3   // Pixels per tick the tracing logo moves at
+	traceSteer     = 0.4 // Share of the way the velocity turns towards the next waypoint per tick
+	traceHoldTicks = 180 // Ticks the finished word stays up before it's traced again
+	traceMargin    = 20  // Least distance of the word from the screen edges
+
+	// Glyphs are drawn on a grid traceGlyphWidth x traceGlyphHeight units,
+	// with traceGlyphGap units between letters
+	traceGlyphWidth  = 4
+	traceGlyphHeight = 6
+	traceGlyphGap    = 2
+)
+
+// traceGlyphs is a stroke font for -trace. Each stroke is a line through
+// grid points written as "xy" pairs, with y growing downwards, and the pen
+// is lifted between strokes.
+var traceGlyphs = map[rune][]string{
+	'A': {"06 01 10 30 41 46", "03 43"},
+	'B': {"06 00 30 41 42 33 03", "33 44 45 36 06"},
+	'C': {"41 30 10 01 05 16 36 45"},
+	'D': {"06 00 30 41 45 36 06"},
+	'E': {"40 00 06 46", "03 33"},
+	'F': {"40 00 06", "03 33"},
+	'G': {"41 30 10 01 05 16 36 45 43 23"},
+	'H': {"00 06", "40 46", "03 43"},
+	'I': {"10 30", "20 26", "16 36"},
+	'J': {"40 45 36 16 05"},
+	'K': {"00 06", "40 03 46"},
+	'L': {"00 06 46"},
+	'M': {"06 00 23 40 46"},
+	'N': {"06 00 46 40"},
+	'O': {"10 30 41 45 36 16 05 01 10"},
+	'P': {"06 00 30 41 42 33 03"},
+	'Q': {"10 30 41 45 36 16 05 01 10", "24 46"},
+	'R': {"06 00 30 41 42 33 03", "23 46"},
+	'S': {"41 30 10 01 02 13 33 44 45 36 16 05"},
+	'T': {"00 40", "20 26"},
+	'U': {"00 05 16 36 45 40"},
+	'V': {"00 26 40"},
+	'W': {"00 16 23 36 40"},
+	'X': {"00 46", "40 06"},
+	'Y': {"00 23 40", "23 26"},
+	'Z': {"00 40 06 46"},
+	'0': {"10 30 41 45 36 16 05 01 10", "05 41"},
+	'1': {"11 20 26", "16 36"},
+	'2': {"01 10 30 41 42 06 46"},
+	'3': {"01 10 30 41 42 33 13", "33 44 45 36 16 05"},
+	'4': {"36 30 03 43"},
+	'5': {"40 00 02 32 43 45 36 06"},
+	'6': {"41 30 10 01 05 16 36 45 44 33 03"},
+	'7': {"00 40 26"},
+	'8': {"13 02 01 10 30 41 42 33 13 04 05 16 36 45 44 33"},
+	'9': {"43 13 02 01 10 30 41 45 36 16 05"},
+	' ': {},
+}
+
+// waypoint is a point of a traced word in glyph grid units, pen tells
+// whether the line to it is drawn
+type waypoint struct {
+	x, y float64
+	pen  bool
+}
+
+// tracer steers a logo along the strokes of a word
+type tracer struct {
+	path    []waypoint
+	columns float64 // Width of the word in grid units
+	next    int     // Index of the waypoint the logo is heading for
+	hold    int     // Ticks left of showing the finished word
+}
+
+// validTraceText rejects text the stroke font has no glyphs for
+func validTraceText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("trace text must not be blank")
+	}
+	for _, r := range strings.ToUpper(text) {
+		if _, ok := traceGlyphs[r]; !ok {
+			return fmt.Errorf("trace text %q has %q, only letters, digits and spaces can be traced", text, r)
+		}
+	}
+	return nil
+}
+
+// newTracer lays out the strokes of text, which must pass validTraceText,
+// one letter after the other
+func newTracer(text string) *tracer {
+	t := &tracer{}
+	for i, r := range []rune(strings.ToUpper(text)) {
+		left := float64(i * (traceGlyphWidth + traceGlyphGap))
+		for _, stroke := range traceGlyphs[r] {
+			for j, p := range strings.Fields(stroke) {
+				t.path = append(t.path, waypoint{
+					x:   left + float64(p[0]-'0'),
+					y:   float64(p[1] - '0'),
+					pen: j > 0,
+				})
+			}
+		}
+		t.columns = left + traceGlyphWidth
+	}
+	return t
+}
+
+// penDown reports whether the logo is drawing the line it's moving along
+func (t *tracer) penDown() bool {
+	return t.hold == 0 && t.path[t.next].pen
+}
+
+// position returns where waypoint w is on a width x height screen, for the
+// center of a logo of logoWidth x logoHeight. The word is scaled to fit the
+// screen with the whole logo on it, so tracing never touches a wall.
+func (t *tracer) position(w waypoint, width, height, logoWidth, logoHeight float64) (float64, float64) {
+	availableW := width - logoWidth - 2*traceMargin
+	availableH := height - logoHeight - 2*traceMargin
+	scale := math.Max(0, math.Min(availableW/t.columns, availableH/traceGlyphHeight))
+	left := (width - t.columns*scale) / 2
+	top := (height - traceGlyphHeight*scale) / 2
+	return left + w.x*scale, top + w.y*scale
+}
+
+// steerTrace turns the first logo towards the next waypoint of the traced
+// word, moving on once it's reached. When the word is done the logo drifts
+// for traceHoldTicks, then the sketch is wiped and the word starts over.
+func (g *Game) steerTrace() {
+	t := g.trace
+	if t.hold > 0 {
+		t.hold--
+		if t.hold == 0 {
+			g.clearSketch()
+		}
+		return
+	}
+
+	l := &g.logos[0]
+	x, y := t.position(t.path[t.next], g.width, g.height, l.width, l.height)
+	dx, dy := x-(l.x+l.width/2), y-(l.y+l.height/2)
+	dist := math.Hypot(dx, dy)
+	if dist <= traceSpeed*g.timeStep()*referenceTPS {
+		t.next++
+		if t.next == len(t.path) {
+			t.next = 0
+			t.hold = traceHoldTicks
+		}
+		return
+	}
+
+	// Blend towards the velocity that heads straight for the waypoint, so the
+	// logo rounds the corners of the letters a little instead of snapping
+	l.velocityX += (dx/dist*traceSpeed - l.velocityX) * traceSteer
+	l.velocityY += (dy/dist*traceSpeed - l.velocityY) * traceSteer
+}
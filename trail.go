@@ -0,0 +1,56 @@
+package dvdlogo
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	trailLength = 32  // Number of past positions kept, the most streakLength can draw
+	trailAlpha  = 0.5 // Opacity of the newest trail image, older ones fade out
+)
+
+type point struct {
+	x, y float64
+}
+
+// trail is a ring buffer of the most recent logo positions
+type trail struct {
+	points [trailLength]point
+	next   int
+	count  int
+}
+
+// push records p, overwriting the oldest position once the buffer is full
+func (t *trail) push(p point) {
+	t.points[t.next] = p
+	t.next = (t.next + 1) % trailLength
+	t.count = min(t.count+1, trailLength)
+}
+
+// clear forgets all stored positions
+func (t *trail) clear() {
+	t.next = 0
+	t.count = 0
+}
+
+// at returns the i-th stored position, with 0 being the oldest
+func (t *trail) at(i int) point {
+	return t.points[(t.next-t.count+i+trailLength)%trailLength]
+}
+
+// drawTrail streaks afterimages behind l while it moves faster than
+// streakSpeed, rendering the newest streakLength positions from oldest to
+// newest with increasing opacity
+func (g *Game) drawTrail(screen *ebiten.Image, l *Logo) {
+	if math.Hypot(l.velocityX, l.velocityY) <= g.config.StreakSpeed {
+		return
+	}
+	n := min(l.trail.count, g.config.StreakLength)
+	for i := 0; i < n; i++ {
+		p := l.trail.at(l.trail.count - n + i)
+		alpha := trailAlpha * float32(i+1) / float32(n+1)
+		g.drawWrapped(screen, l, p.x, p.y, alpha)
+	}
+}
@@ -0,0 +1,73 @@
+package dvdlogo
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// wrapAround moves a logo that left a width x height screen back in from the
+// opposite edge. The previous position moves along, so drawing keeps
+// sliding it the same way instead of back across the whole screen.
+func (l *Logo) wrapAround(width, height float64) {
+	// With no room left on an axis, such as when the margins take up the whole
+	// window, there's no opposite edge to come in from
+	if width <= 0 || height <= 0 {
+		l.constrain(width, height)
+		return
+	}
+	shiftX := math.Floor(l.x/width) * width
+	shiftY := math.Floor(l.y/height) * height
+	if shiftX == 0 && shiftY == 0 {
+		return
+	}
+	l.x -= shiftX
+	l.y -= shiftY
+	l.prevX -= shiftX
+	l.prevY -= shiftY
+	// The old positions are on the other side now and would be sketched across the screen
+	l.trail.clear()
+}
+
+// keepOnScreen brings a logo pushed off the screen back, from the opposite
+// edge in wrap mode and by stopping it at the wall otherwise
+func (g *Game) keepOnScreen(l *Logo) {
+	if g.wrap {
+		l.wrapAround(g.width, g.height)
+		return
+	}
+	l.constrain(g.width, g.height)
+}
+
+// drawWrapped draws l at x, y like drawLogo, and in wrap mode also draws the
+// part of it sticking out over an edge at the opposite edge
+func (g *Game) drawWrapped(screen *ebiten.Image, l *Logo, x, y float64, alpha float32) {
+	g.drawLogo(screen, l, x, y, alpha)
+	if !g.wrap {
+		return
+	}
+	dx := wrapOffset(x, l.width, g.width)
+	dy := wrapOffset(y, l.height, g.height)
+	if dx != 0 {
+		g.drawLogo(screen, l, x+dx, y, alpha)
+	}
+	if dy != 0 {
+		g.drawLogo(screen, l, x, y+dy, alpha)
+	}
+	if dx != 0 && dy != 0 {
+		g.drawLogo(screen, l, x+dx, y+dy, alpha)
+	}
+}
+
+// wrapOffset returns how far to move something at pos of the given size so
+// the part of it hanging over an edge of a screen of length shows at the
+// other edge, or 0 if it's fully on the screen
+func wrapOffset(pos, size, length float64) float64 {
+	switch {
+	case pos < 0:
+		return length
+	case pos+size > length:
+		return -length
+	}
+	return 0
+}
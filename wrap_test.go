@@ -0,0 +1,28 @@
+package dvdlogo
+
+import "testing"
+
+func TestWrapAround(t *testing.T) {
+	tests := []struct {
+		name          string
+		x, y          float64
+		width, height float64
+		wantX, wantY  float64
+	}{
+		{"on the screen", 100, 50, testWidth, testHeight, 100, 50},
+		{"off the left", -30, 50, testWidth, testHeight, testWidth - 30, 50},
+		{"off the bottom right", testWidth + 10, testHeight + 20, testWidth, testHeight, 10, 20},
+		// Pinned to the edge rather than divided by zero
+		{"no width", 30, 50, 0, testHeight, 0, 50},
+		{"no height", 30, 50, testWidth, 0, 30, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := testLogo(tt.x, tt.y, 2, 2)
+			l.wrapAround(tt.width, tt.height)
+			if l.x != tt.wantX || l.y != tt.wantY {
+				t.Errorf("wrapped to %v, %v, want %v, %v", l.x, l.y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}